@@ -0,0 +1,225 @@
+// Package ass reads and writes Advanced SubStation Alpha (ASS) and legacy
+// SubStation Alpha (SSA) files using the same data model as the SRT parser.
+package ass
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/florentsorel/srt/model"
+)
+
+// defaultEventFormat is used when an [Events] section has no Format line.
+var defaultEventFormat = []string{"Layer", "Start", "End", "Style", "Name", "MarginL", "MarginR", "MarginV", "Effect", "Text"}
+
+// Open reads the ASS or SSA file at the given path, parses its content,
+// and returns a Subtitles struct or an error if reading or parsing fails.
+func Open(path string) (*model.Subtitles, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Parse reads from the provided io.Reader, parses the ASS or SSA content,
+// and returns a Subtitles struct or an error if parsing fails.
+//
+// Each Dialogue line becomes a Cue. Override blocks are translated to SRT
+// markup where possible (<i>, <b>, <u>, <font color>, {\anN}) and dropped
+// otherwise; the italic, bold, underline, colour and alignment of the line's
+// style are applied the same way. Dialogue lines left without text, such as
+// drawings, are skipped. Cues are sorted by start time and renumbered.
+func Parse(r io.Reader) (*model.Subtitles, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var (
+		section     string
+		styleFormat []string
+		eventFormat = splitFormat(strings.Join(defaultEventFormat, ","))
+		styles      = map[string]Style{}
+		cues        []model.Cue
+		lineNumber  int
+	)
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if lineNumber == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if line == "" || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.ToLower(line)
+			continue
+		}
+
+		colon := strings.IndexByte(line, ':')
+		if colon < 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:colon])
+		value := strings.TrimSpace(line[colon+1:])
+
+		switch section {
+		case "[v4+ styles]", "[v4 styles]":
+			switch key {
+			case "Format":
+				styleFormat = splitFormat(value)
+			case "Style":
+				if styleFormat == nil {
+					return nil, fmt.Errorf("style defined before Format at line %d", lineNumber)
+				}
+				style := parseStyle(splitFields(value, styleFormat), section == "[v4 styles]")
+				styles[style.Name] = style
+			}
+		case "[events]":
+			switch key {
+			case "Format":
+				eventFormat = splitFormat(value)
+			case "Dialogue":
+				fields := splitFields(value, eventFormat)
+
+				start, err := parseTime(fields["start"])
+				if err != nil {
+					return nil, fmt.Errorf("%v at line %d", err, lineNumber)
+				}
+				end, err := parseTime(fields["end"])
+				if err != nil {
+					return nil, fmt.Errorf("%v at line %d", err, lineNumber)
+				}
+
+				style, ok := styles[strings.TrimPrefix(fields["style"], "*")]
+				if !ok {
+					style = DefaultStyle
+				}
+
+				text := toSRT(fields["text"], style)
+				if text == "" {
+					continue
+				}
+
+				cues = append(cues, model.Cue{Start: start, End: end, Text: text})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(cues, func(i, j int) bool {
+		return cues[i].Start < cues[j].Start
+	})
+	for i := range cues {
+		cues[i].Index = i + 1
+	}
+
+	return &model.Subtitles{Items: cues}, nil
+}
+
+// splitFormat splits the value of a Format line into lower-cased field names.
+func splitFormat(value string) []string {
+	names := strings.Split(value, ",")
+	for i, name := range names {
+		names[i] = strings.ToLower(strings.TrimSpace(name))
+	}
+	return names
+}
+
+// splitFields splits the value of a Style or Dialogue line according to
+// format. The last field takes the rest of the line, as the text of a
+// Dialogue line may contain commas.
+func splitFields(value string, format []string) map[string]string {
+	values := strings.SplitN(value, ",", len(format))
+	fields := make(map[string]string, len(format))
+	for i, v := range values {
+		if format[i] == "text" {
+			fields[format[i]] = v
+		} else {
+			fields[format[i]] = strings.TrimSpace(v)
+		}
+	}
+	return fields
+}
+
+// parseTime parses an ASS timestamp in the format "H:MM:SS.cc" and returns a
+// model.Duration.
+func parseTime(s string) (model.Duration, error) {
+	var h, m, sec, cs int
+	if _, err := fmt.Sscanf(s, "%d:%02d:%02d.%02d", &h, &m, &sec, &cs); err != nil {
+		return 0, fmt.Errorf("invalid timestamp %q", s)
+	}
+	if h < 0 || m > 59 || sec > 59 || cs > 99 {
+		return 0, fmt.Errorf("invalid timestamp %q", s)
+	}
+
+	d := time.Duration(h)*time.Hour +
+		time.Duration(m)*time.Minute +
+		time.Duration(sec)*time.Second +
+		time.Duration(cs)*10*time.Millisecond
+
+	return model.Duration(d), nil
+}
+
+// formatTime formats d as an ASS timestamp "H:MM:SS.cc", rounded to the
+// nearest centisecond. Negative durations are written as zero.
+func formatTime(d model.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	cs := (time.Duration(d) + 5*time.Millisecond) / (10 * time.Millisecond)
+	return fmt.Sprintf("%d:%02d:%02d.%02d", cs/360000, cs/6000%60, cs/100%60, cs%100)
+}
+
+// Write writes the Subtitles in ASS format to the given io.Writer. The given
+// styles make up the [V4+ Styles] section and every cue uses the first one;
+// DefaultStyle is used when no style is given. SRT markup in the cue text is
+// translated to override blocks.
+func Write(writer io.Writer, s model.Subtitles, styles ...Style) (int, error) {
+	if len(styles) == 0 {
+		styles = []Style{DefaultStyle}
+	}
+
+	var b strings.Builder
+
+	b.WriteString("[Script Info]\n")
+	b.WriteString("ScriptType: v4.00+\n")
+	b.WriteString("WrapStyle: 0\n")
+	b.WriteString("ScaledBorderAndShadow: yes\n")
+	b.WriteString("PlayResX: 1920\n")
+	b.WriteString("PlayResY: 1080\n")
+
+	b.WriteString("\n[V4+ Styles]\n")
+	b.WriteString("Format: " + strings.Join(styleFields, ", ") + "\n")
+	for _, style := range styles {
+		b.WriteString(style.line())
+		b.WriteByte('\n')
+	}
+
+	b.WriteString("\n[Events]\n")
+	b.WriteString("Format: " + strings.Join(defaultEventFormat, ", ") + "\n")
+	for _, cue := range s.Items {
+		b.WriteString("Dialogue: 0,")
+		b.WriteString(formatTime(cue.Start))
+		b.WriteByte(',')
+		b.WriteString(formatTime(cue.End))
+		b.WriteByte(',')
+		b.WriteString(styles[0].Name)
+		b.WriteString(",,0,0,0,,")
+		b.WriteString(fromSRT(cue.Text))
+		b.WriteByte('\n')
+	}
+
+	return writer.Write([]byte(b.String()))
+}
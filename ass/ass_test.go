@@ -0,0 +1,107 @@
+package ass
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/florentsorel/srt/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const sample = `[Script Info]
+; Comment
+Title: Sample
+ScriptType: v4.00+
+
+[V4+ Styles]
+Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding
+Style: Default,Arial,20,&H00FFFFFF,&H000000FF,&H00000000,&H00000000,0,0,0,0,100,100,0,0,1,2,2,2,10,10,10,1
+Style: Thoughts,Arial,20,&H00FFFFFF,&H000000FF,&H00000000,&H00000000,0,-1,0,0,100,100,0,0,1,2,2,2,10,10,10,1
+Style: Signs,Arial,20,&H00FFFFFF,&H000000FF,&H00000000,&H00000000,-1,0,0,0,100,100,0,0,1,2,2,8,10,10,10,1
+
+[Events]
+Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text
+Dialogue: 0,0:00:05.50,0:00:07.00,Thoughts,,0,0,0,,I wonder, really.
+Dialogue: 0,0:00:01.00,0:00:04.25,Default,,0,0,0,,Hello, {\i1}world{\i0}!\NSecond line
+Comment: 0,0:00:02.00,0:00:03.00,Default,,0,0,0,,Not shown
+Dialogue: 0,0:00:08.00,0:00:09.00,Signs,,0,0,0,,{\fad(200,200)}EXIT
+Dialogue: 0,0:00:10.00,0:00:11.00,Default,,0,0,0,,{\an8\c&H0000FF&}Red{\c} and {\b1\u1}both{\r} plain
+Dialogue: 0,0:00:12.00,0:00:13.00,Default,,0,0,0,,{\p1}m 0 0 l 100 0 100 100{\p0}
+`
+
+func TestParse(t *testing.T) {
+	subtitles, err := Parse(strings.NewReader(sample))
+	assert.NoError(t, err, "Expected no error from Parse")
+
+	expected := []model.Cue{
+		{Index: 1, Start: model.Duration(time.Second), End: model.Duration(4*time.Second + 250*time.Millisecond), Text: "Hello, <i>world</i>!\nSecond line"},
+		{Index: 2, Start: model.Duration(5*time.Second + 500*time.Millisecond), End: model.Duration(7 * time.Second), Text: "<i>I wonder, really.</i>"},
+		{Index: 3, Start: model.Duration(8 * time.Second), End: model.Duration(9 * time.Second), Text: `{\an8}<b>EXIT</b>`},
+		{Index: 4, Start: model.Duration(10 * time.Second), End: model.Duration(11 * time.Second), Text: `{\an8}<font color="#FF0000">Red</font> and <b><u>both</u></b> plain`},
+	}
+	assert.Equal(t, expected, subtitles.Items)
+}
+
+func TestParseLegacySSA(t *testing.T) {
+	input := "[Script Info]\nScriptType: v4.00\n\n[V4 Styles]\n" +
+		"Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, TertiaryColour, BackColour, Bold, Italic, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, AlphaLevel, Encoding\n" +
+		"Style: Top,Arial,20,16777215,255,0,0,0,0,1,2,2,6,10,10,10,0,1\n\n" +
+		"[Events]\nFormat: Marked, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n" +
+		"Dialogue: Marked=0,0:00:01.00,0:00:02.00,Top,,0000,0000,0000,,Top line\n"
+
+	subtitles, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err, "Expected no error from Parse")
+	if assert.Len(t, subtitles.Items, 1) {
+		assert.Equal(t, `{\an8}Top line`, subtitles.Items[0].Text)
+	}
+}
+
+func TestParseInvalidTimestamp(t *testing.T) {
+	input := "[Events]\nFormat: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\nDialogue: 0,0:00:01,0:00:02.00,Default,,0,0,0,,Text\n"
+	_, err := Parse(strings.NewReader(input))
+	assert.EqualError(t, err, `invalid timestamp "0:00:01" at line 3`)
+}
+
+func TestWrite(t *testing.T) {
+	subtitles := model.Subtitles{
+		Items: []model.Cue{
+			{Index: 1, Start: model.Duration(1 * time.Second), End: model.Duration(3*time.Second + 456*time.Millisecond), Text: "<i>First</i>\nline"},
+			{Index: 2, Start: model.Duration(4 * time.Second), End: model.Duration(6 * time.Second), Text: `{\an8}<font color="#FF8000">Second</font>`},
+		},
+	}
+
+	var sb strings.Builder
+	_, err := Write(&sb, subtitles)
+	assert.NoError(t, err, "Expected no error from Write")
+
+	out := sb.String()
+	assert.Contains(t, out, "Style: Default,Arial,48,&H00FFFFFF,&H000000FF,&H00000000,&H00000000,0,0,0,0,100,100,0,0,1,2,2,2,10,10,10,1\n")
+	assert.Contains(t, out, "Dialogue: 0,0:00:01.00,0:00:03.46,Default,,0,0,0,,{\\i1}First{\\i0}\\Nline\n")
+	assert.Contains(t, out, "Dialogue: 0,0:00:04.00,0:00:06.00,Default,,0,0,0,,{\\an8}{\\c&H0080FF&}Second{\\c}\n")
+
+	custom := DefaultStyle
+	custom.Name = "Main"
+	sb.Reset()
+	_, err = Write(&sb, subtitles, custom)
+	assert.NoError(t, err, "Expected no error from Write")
+	assert.Contains(t, sb.String(), "Style: Main,")
+	assert.Contains(t, sb.String(), ",Main,,0,0,0,,")
+}
+
+func TestRoundTrip(t *testing.T) {
+	subtitles := model.Subtitles{
+		Items: []model.Cue{
+			{Index: 1, Start: model.Duration(1 * time.Second), End: model.Duration(3 * time.Second), Text: "<i>First</i>\nline"},
+			{Index: 2, Start: model.Duration(4 * time.Second), End: model.Duration(6 * time.Second), Text: `{\an8}<b>Second</b> <font color="#FF8000">part</font>`},
+		},
+	}
+
+	var sb strings.Builder
+	_, err := Write(&sb, subtitles)
+	assert.NoError(t, err, "Expected no error from Write")
+
+	parsed, err := Parse(strings.NewReader(sb.String()))
+	assert.NoError(t, err, "Expected no error from Parse")
+	assert.Equal(t, subtitles.Items, parsed.Items)
+}
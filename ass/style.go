@@ -0,0 +1,177 @@
+package ass
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Style is a style definition from the [V4+ Styles] section.
+type Style struct {
+	Name            string
+	Fontname        string
+	Fontsize        float64
+	PrimaryColour   string
+	SecondaryColour string
+	OutlineColour   string
+	BackColour      string
+	Bold            bool
+	Italic          bool
+	Underline       bool
+	StrikeOut       bool
+	ScaleX          float64
+	ScaleY          float64
+	Spacing         float64
+	Angle           float64
+	BorderStyle     int
+	Outline         float64
+	Shadow          float64
+	// Alignment uses the numpad layout of \an: 1-3 bottom, 4-6 middle,
+	// 7-9 top.
+	Alignment int
+	MarginL   int
+	MarginR   int
+	MarginV   int
+	Encoding  int
+}
+
+// DefaultStyle is the style used by Write when none is supplied.
+var DefaultStyle = Style{
+	Name:            "Default",
+	Fontname:        "Arial",
+	Fontsize:        48,
+	PrimaryColour:   "&H00FFFFFF",
+	SecondaryColour: "&H000000FF",
+	OutlineColour:   "&H00000000",
+	BackColour:      "&H00000000",
+	ScaleX:          100,
+	ScaleY:          100,
+	BorderStyle:     1,
+	Outline:         2,
+	Shadow:          2,
+	Alignment:       2,
+	MarginL:         10,
+	MarginR:         10,
+	MarginV:         10,
+	Encoding:        1,
+}
+
+// styleFields is the field order used when writing styles.
+var styleFields = []string{
+	"Name", "Fontname", "Fontsize", "PrimaryColour", "SecondaryColour", "OutlineColour", "BackColour",
+	"Bold", "Italic", "Underline", "StrikeOut", "ScaleX", "ScaleY", "Spacing", "Angle",
+	"BorderStyle", "Outline", "Shadow", "Alignment", "MarginL", "MarginR", "MarginV", "Encoding",
+}
+
+// parseStyle builds a Style from the values of a Style line, mapped by the
+// field names of the section's Format line. Legacy SSA alignments are
+// converted to the numpad layout.
+func parseStyle(fields map[string]string, legacy bool) Style {
+	s := Style{
+		Name:            fields["name"],
+		Fontname:        fields["fontname"],
+		Fontsize:        parseFloat(fields["fontsize"]),
+		PrimaryColour:   fields["primarycolour"],
+		SecondaryColour: fields["secondarycolour"],
+		OutlineColour:   fields["outlinecolour"],
+		BackColour:      fields["backcolour"],
+		Bold:            parseBool(fields["bold"]),
+		Italic:          parseBool(fields["italic"]),
+		Underline:       parseBool(fields["underline"]),
+		StrikeOut:       parseBool(fields["strikeout"]),
+		ScaleX:          parseFloat(fields["scalex"]),
+		ScaleY:          parseFloat(fields["scaley"]),
+		Spacing:         parseFloat(fields["spacing"]),
+		Angle:           parseFloat(fields["angle"]),
+		BorderStyle:     parseInt(fields["borderstyle"]),
+		Outline:         parseFloat(fields["outline"]),
+		Shadow:          parseFloat(fields["shadow"]),
+		Alignment:       parseInt(fields["alignment"]),
+		MarginL:         parseInt(fields["marginl"]),
+		MarginR:         parseInt(fields["marginr"]),
+		MarginV:         parseInt(fields["marginv"]),
+		Encoding:        parseInt(fields["encoding"]),
+	}
+
+	// SSA uses "TertiaryColour" where ASS uses "OutlineColour".
+	if s.OutlineColour == "" {
+		s.OutlineColour = fields["tertiarycolour"]
+	}
+	if legacy {
+		s.Alignment = legacyAlignment(s.Alignment)
+	}
+	if s.Alignment < 1 || s.Alignment > 9 {
+		s.Alignment = 2
+	}
+
+	return s
+}
+
+// legacyAlignment converts an SSA alignment (1-3 bottom, 5-7 top, 9-11
+// middle) to the numpad layout used by ASS.
+func legacyAlignment(a int) int {
+	switch {
+	case a >= 1 && a <= 3:
+		return a
+	case a >= 5 && a <= 7:
+		return a + 2
+	case a >= 9 && a <= 11:
+		return a - 5
+	}
+	return 2
+}
+
+// line returns the style as a Style line of the [V4+ Styles] section.
+func (s Style) line() string {
+	values := []string{
+		s.Name,
+		s.Fontname,
+		formatFloat(s.Fontsize),
+		s.PrimaryColour,
+		s.SecondaryColour,
+		s.OutlineColour,
+		s.BackColour,
+		formatBool(s.Bold),
+		formatBool(s.Italic),
+		formatBool(s.Underline),
+		formatBool(s.StrikeOut),
+		formatFloat(s.ScaleX),
+		formatFloat(s.ScaleY),
+		formatFloat(s.Spacing),
+		formatFloat(s.Angle),
+		strconv.Itoa(s.BorderStyle),
+		formatFloat(s.Outline),
+		formatFloat(s.Shadow),
+		strconv.Itoa(s.Alignment),
+		strconv.Itoa(s.MarginL),
+		strconv.Itoa(s.MarginR),
+		strconv.Itoa(s.MarginV),
+		strconv.Itoa(s.Encoding),
+	}
+	return "Style: " + strings.Join(values, ",")
+}
+
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return f
+}
+
+func parseInt(s string) int {
+	i, _ := strconv.Atoi(strings.TrimSpace(s))
+	return i
+}
+
+// parseBool parses an ASS boolean, where -1 (or any non-zero value) is true.
+func parseBool(s string) bool {
+	return parseInt(s) != 0
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatBool(b bool) string {
+	if b {
+		return "-1"
+	}
+	return "0"
+}
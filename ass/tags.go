@@ -0,0 +1,235 @@
+package ass
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/florentsorel/srt/model"
+)
+
+// toSRT converts the text of a Dialogue line to SRT markup.
+func toSRT(text string, style Style) string {
+	base := model.Span{Italic: style.Italic, Bold: style.Bold, Underline: style.Underline}
+	state := base
+	alignment := 0
+	drawing := false
+
+	var spans []model.Span
+	add := func(s string) {
+		if !drawing {
+			span := state
+			span.Text = s
+			spans = append(spans, span)
+		}
+	}
+
+	for len(text) > 0 {
+		switch {
+		case text[0] == '{':
+			end := strings.IndexByte(text, '}')
+			if end < 0 {
+				add(text)
+				text = ""
+				continue
+			}
+
+			block := text[1:end]
+			text = text[end+1:]
+
+			// Blocks without a backslash are comments.
+			for _, tag := range strings.Split(block, `\`)[1:] {
+				applyTag(strings.TrimSpace(tag), &state, base, &alignment, &drawing)
+			}
+		case strings.HasPrefix(text, `\N`) || strings.HasPrefix(text, `\n`):
+			add("\n")
+			text = text[2:]
+		case strings.HasPrefix(text, `\h`):
+			add(" ")
+			text = text[2:]
+		default:
+			next := strings.IndexAny(text[1:], `{\`)
+			chunk := text
+			if next >= 0 {
+				chunk = text[:next+1]
+			}
+			add(chunk)
+			text = text[len(chunk):]
+		}
+	}
+
+	spans = trimSpans(spans)
+	if len(spans) == 0 {
+		return ""
+	}
+
+	if alignment == 0 {
+		alignment = style.Alignment
+	}
+	rich := model.RichText{Spans: spans}
+	if alignment != 2 {
+		rich.Alignment = model.Alignment(alignment)
+	}
+
+	return rich.String()
+}
+
+// trimSpans removes the leading and trailing white space of the text
+// formed by spans, dropping the spans left empty.
+func trimSpans(spans []model.Span) []model.Span {
+	for len(spans) > 0 {
+		spans[0].Text = strings.TrimLeftFunc(spans[0].Text, unicode.IsSpace)
+		if spans[0].Text != "" {
+			break
+		}
+		spans = spans[1:]
+	}
+	for len(spans) > 0 {
+		last := len(spans) - 1
+		spans[last].Text = strings.TrimRightFunc(spans[last].Text, unicode.IsSpace)
+		if spans[last].Text != "" {
+			break
+		}
+		spans = spans[:last]
+	}
+	return spans
+}
+
+// applyTag updates the formatting state with a single override tag, given
+// without its leading backslash.
+func applyTag(tag string, state *model.Span, base model.Span, alignment *int, drawing *bool) {
+	switch {
+	case strings.HasPrefix(tag, "an"):
+		if a, err := strconv.Atoi(tag[2:]); err == nil && a >= 1 && a <= 9 && *alignment == 0 {
+			*alignment = a
+		}
+	case isToggle(tag, "a"):
+		if a, err := strconv.Atoi(tag[1:]); err == nil && *alignment == 0 {
+			*alignment = legacyAlignment(a)
+		}
+	case isToggle(tag, "i"):
+		state.Italic = toggleValue(tag[1:], base.Italic)
+	case isToggle(tag, "b"):
+		if tag == "b" {
+			state.Bold = base.Bold
+		} else if weight, err := strconv.Atoi(tag[1:]); err == nil {
+			state.Bold = weight == 1 || weight >= 600
+		}
+	case isToggle(tag, "u"):
+		state.Underline = toggleValue(tag[1:], base.Underline)
+	case tag == "c" || tag == "1c":
+		state.Color = base.Color
+	case strings.HasPrefix(tag, "c&") || strings.HasPrefix(tag, "1c&"):
+		state.Color = srtColor(tag[strings.IndexByte(tag, '&'):])
+	case tag == "r" || (strings.HasPrefix(tag, "r") && !strings.HasPrefix(tag, "rnd")):
+		*state = base
+	case isToggle(tag, "p"):
+		if p, err := strconv.Atoi(tag[1:]); err == nil {
+			*drawing = p > 0
+		}
+	}
+}
+
+// isToggle reports whether tag is the given name alone or followed by digits,
+// which tells \i1 apart from \iclip or \b1 from \blur.
+func isToggle(tag, name string) bool {
+	if !strings.HasPrefix(tag, name) {
+		return false
+	}
+	rest := tag[len(name):]
+	for i := 0; i < len(rest); i++ {
+		if rest[i] < '0' || rest[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// toggleValue parses the value of a boolean tag; an empty value resets the
+// tag to the style default.
+func toggleValue(value string, def bool) bool {
+	if value == "" {
+		return def
+	}
+	return value != "0"
+}
+
+// srtColor converts an ASS colour (&HBBGGRR& or &HAABBGGRR) to "#RRGGBB".
+func srtColor(c string) string {
+	c = strings.Trim(strings.TrimPrefix(strings.TrimPrefix(c, "&"), "H"), "&")
+	v, err := strconv.ParseUint(c, 16, 32)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("#%02X%02X%02X", v&0xFF, v>>8&0xFF, v>>16&0xFF)
+}
+
+// assColor converts an SRT colour "#RRGGBB" to "&HBBGGRR&".
+func assColor(c string) string {
+	v, err := strconv.ParseUint(strings.TrimPrefix(c, "#"), 16, 32)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("&H%02X%02X%02X&", v&0xFF, v>>8&0xFF, v>>16&0xFF)
+}
+
+// fromSRT converts SRT markup to the text of a Dialogue line. Formatting
+// changes are written as override blocks and reset at the end of the line.
+func fromSRT(text string) string {
+	rich := model.ParseRichText(strings.ReplaceAll(text, "\r\n", "\n"))
+
+	var b strings.Builder
+	if rich.Alignment != model.AlignDefault {
+		b.WriteString(`{\an` + strconv.Itoa(int(rich.Alignment)) + "}")
+	}
+
+	var prev model.Span
+	for _, span := range append(rich.Spans, model.Span{}) {
+		b.WriteString(overrides(prev, span))
+		b.WriteString(strings.ReplaceAll(span.Text, "\n", `\N`))
+		prev = span
+	}
+
+	return b.String()
+}
+
+// overrides returns the override block switching the formatting of from to
+// the formatting of to, or an empty string if they look the same.
+func overrides(from, to model.Span) string {
+	var tags []string
+	if from.Italic != to.Italic {
+		tags = append(tags, `\i`+toggle(to.Italic))
+	}
+	if from.Bold != to.Bold {
+		tags = append(tags, `\b`+toggle(to.Bold))
+	}
+	if from.Underline != to.Underline {
+		tags = append(tags, `\u`+toggle(to.Underline))
+	}
+	if color := assColor(to.Color); color != assColor(from.Color) {
+		tags = append(tags, `\c`+color)
+	}
+	if from.Face != to.Face {
+		tags = append(tags, `\fn`+to.Face)
+	}
+	if from.Size != to.Size {
+		size := ""
+		if to.Size != 0 {
+			size = strconv.Itoa(to.Size)
+		}
+		tags = append(tags, `\fs`+size)
+	}
+
+	if len(tags) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(tags, "") + "}"
+}
+
+func toggle(on bool) string {
+	if on {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,214 @@
+// Package charset detects the character encoding of subtitle files and
+// transcodes them from and to UTF-8.
+package charset
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding identifies a character encoding by its IANA name.
+type Encoding string
+
+const (
+	UTF8        Encoding = "UTF-8"
+	UTF16LE     Encoding = "UTF-16LE"
+	UTF16BE     Encoding = "UTF-16BE"
+	Windows1250 Encoding = "windows-1250"
+	Windows1251 Encoding = "windows-1251"
+	Windows1252 Encoding = "windows-1252"
+	ISO88591    Encoding = "ISO-8859-1"
+	ISO88592    Encoding = "ISO-8859-2"
+	ISO885915   Encoding = "ISO-8859-15"
+	KOI8R       Encoding = "KOI8-R"
+)
+
+// ErrUnsupported is returned for encodings not supported by this package.
+var ErrUnsupported = errors.New("unsupported encoding")
+
+// ErrUnrepresentable is returned when text contains a character that cannot
+// be represented in the target encoding.
+var ErrUnrepresentable = errors.New("character not representable in encoding")
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// singleByte maps single-byte encodings to their decoding tables.
+var singleByte = map[Encoding]*[128]rune{
+	Windows1250: &windows1250,
+	Windows1251: &windows1251,
+	Windows1252: &windows1252,
+	ISO88591:    &iso88591,
+	ISO88592:    &iso88592,
+	ISO885915:   &iso885915,
+	KOI8R:       &koi8R,
+}
+
+// Decode converts b from the given encoding to a UTF-8 string. A leading
+// byte order mark matching the encoding is removed.
+func Decode(b []byte, enc Encoding) (string, error) {
+	switch enc {
+	case UTF8:
+		b = bytes.TrimPrefix(b, bomUTF8)
+		if !utf8.Valid(b) {
+			return "", fmt.Errorf("invalid %s input", enc)
+		}
+		return string(b), nil
+	case UTF16LE, UTF16BE:
+		return decodeUTF16(b, enc)
+	}
+
+	table, ok := singleByte[enc]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnsupported, enc)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(b))
+	for i, c := range b {
+		if c < 0x80 {
+			sb.WriteByte(c)
+			continue
+		}
+		r := table[c-0x80]
+		if r == utf8.RuneError {
+			return "", fmt.Errorf("invalid %s byte 0x%02X at offset %d", enc, c, i)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String(), nil
+}
+
+// decodeUTF16 decodes UTF-16 input with the byte order of enc.
+func decodeUTF16(b []byte, enc Encoding) (string, error) {
+	if enc == UTF16LE {
+		b = bytes.TrimPrefix(b, bomUTF16LE)
+	} else {
+		b = bytes.TrimPrefix(b, bomUTF16BE)
+	}
+	if len(b)%2 != 0 {
+		return "", fmt.Errorf("invalid %s input: odd length", enc)
+	}
+
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		if enc == UTF16LE {
+			units[i] = uint16(b[2*i]) | uint16(b[2*i+1])<<8
+		} else {
+			units[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+		}
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// Encode converts the UTF-8 string s to the given encoding. No byte order
+// mark is added.
+func Encode(s string, enc Encoding) ([]byte, error) {
+	switch enc {
+	case UTF8:
+		return []byte(s), nil
+	case UTF16LE, UTF16BE:
+		units := utf16.Encode([]rune(s))
+		b := make([]byte, 0, 2*len(units))
+		for _, u := range units {
+			if enc == UTF16LE {
+				b = append(b, byte(u), byte(u>>8))
+			} else {
+				b = append(b, byte(u>>8), byte(u))
+			}
+		}
+		return b, nil
+	}
+
+	table, ok := singleByte[enc]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupported, enc)
+	}
+
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r < 0x80 {
+			b = append(b, byte(r))
+			continue
+		}
+		c, ok := encodeRune(table, r)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q in %s", ErrUnrepresentable, r, enc)
+		}
+		b = append(b, c)
+	}
+	return b, nil
+}
+
+// encodeRune returns the byte encoding r in the given decoding table.
+func encodeRune(table *[128]rune, r rune) (byte, bool) {
+	for i, t := range table {
+		if t == r && t != utf8.RuneError {
+			return byte(0x80 + i), true
+		}
+	}
+	return 0, false
+}
+
+// BOM returns the byte order mark of the encoding, or nil if it has none.
+func BOM(enc Encoding) []byte {
+	switch enc {
+	case UTF8:
+		return bomUTF8
+	case UTF16LE:
+		return bomUTF16LE
+	case UTF16BE:
+		return bomUTF16BE
+	}
+	return nil
+}
+
+// Writer transcodes UTF-8 text written to it into another encoding.
+type Writer struct {
+	w       io.Writer
+	enc     Encoding
+	pending []byte
+}
+
+// NewWriter returns a Writer that encodes UTF-8 text to enc before writing
+// it to w. It can be passed to model.Subtitles.Write to write a track in a
+// legacy encoding. No byte order mark is written; use BOM to add one.
+func NewWriter(w io.Writer, enc Encoding) *Writer {
+	return &Writer{w: w, enc: enc}
+}
+
+// Write encodes p and writes it to the underlying writer. UTF-8 sequences
+// split across calls are buffered until complete. It returns len(p) on
+// success, the number of UTF-8 bytes consumed.
+func (w *Writer) Write(p []byte) (int, error) {
+	buf := append(w.pending, p...)
+
+	// Keep an incomplete trailing sequence for the next call.
+	end := len(buf)
+	for i := 1; i < utf8.UTFMax && i <= len(buf); i++ {
+		if utf8.RuneStart(buf[len(buf)-i]) {
+			if !utf8.FullRune(buf[len(buf)-i:]) {
+				end = len(buf) - i
+			}
+			break
+		}
+	}
+	w.pending = append([]byte(nil), buf[end:]...)
+
+	encoded, err := Encode(string(buf[:end]), w.enc)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := w.w.Write(encoded); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
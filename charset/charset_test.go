@@ -0,0 +1,94 @@
+package charset
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		enc      Encoding
+		expected string
+	}{
+		{"utf8_bom", []byte("\xef\xbb\xbfCafé"), UTF8, "Café"},
+		{"utf16le_bom", []byte{0xFF, 0xFE, 'C', 0, 'a', 0, 'f', 0, 0xE9, 0}, UTF16LE, "Café"},
+		{"utf16be", []byte{0, 'C', 0, 'a', 0, 'f', 0, 0xE9}, UTF16BE, "Café"},
+		{"windows1252", []byte("Caf\xe9 \x80"), Windows1252, "Café €"},
+		{"iso885915", []byte("Caf\xe9 \xa4"), ISO885915, "Café €"},
+		{"windows1251", []byte("\xcf\xf0\xe8\xe2\xe5\xf2"), Windows1251, "Привет"},
+		{"koi8r", []byte("\xf0\xd2\xc9\xd7\xc5\xd4"), KOI8R, "Привет"},
+		{"windows1250", []byte("\x9ala \xe8"), Windows1250, "šla č"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(tt.input, tt.enc)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	_, err := Decode([]byte{0x81}, Windows1252)
+	assert.EqualError(t, err, "invalid windows-1252 byte 0x81 at offset 0")
+
+	_, err = Decode([]byte("\xff"), UTF8)
+	assert.Error(t, err)
+
+	_, err = Decode([]byte("abc"), Encoding("EBCDIC"))
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestEncode(t *testing.T) {
+	b, err := Encode("Café €", Windows1252)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("Caf\xe9 \x80"), b)
+
+	b, err = Encode("Привет", Windows1251)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("\xcf\xf0\xe8\xe2\xe5\xf2"), b)
+
+	b, err = Encode("Café", UTF16LE)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{'C', 0, 'a', 0, 'f', 0, 0xE9, 0}, b)
+
+	_, err = Encode("Привет", Windows1252)
+	assert.ErrorIs(t, err, ErrUnrepresentable)
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, Windows1252)
+
+	// "é" is split across two writes.
+	n, err := w.Write([]byte("Caf\xc3"))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+	n, err = w.Write([]byte("\xa9 ok"))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+
+	assert.Equal(t, []byte("Caf\xe9 ok"), buf.Bytes())
+}
+
+func TestBOM(t *testing.T) {
+	assert.Equal(t, []byte{0xEF, 0xBB, 0xBF}, BOM(UTF8))
+	assert.Equal(t, []byte{0xFF, 0xFE}, BOM(UTF16LE))
+	assert.Nil(t, BOM(Windows1252))
+}
+
+func TestRoundTrip(t *testing.T) {
+	text := "Les élèves ont déjà mangé à l'école, ça va ?"
+	for _, enc := range []Encoding{UTF8, UTF16LE, UTF16BE, Windows1252, ISO88591, ISO885915} {
+		b, err := Encode(text, enc)
+		assert.NoError(t, err, "%s", enc)
+		got, err := Decode(b, enc)
+		assert.NoError(t, err, "%s", enc)
+		assert.Equal(t, text, got, "%s", enc)
+	}
+}
@@ -0,0 +1,142 @@
+package charset
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// candidates lists the legacy encodings considered by Detect, in order of
+// preference when several score the same.
+var candidates = []Encoding{
+	Windows1252,
+	ISO885915,
+	Windows1250,
+	ISO88592,
+	Windows1251,
+	KOI8R,
+	ISO88591,
+}
+
+// Detect guesses the encoding of b. Byte order marks are trusted first, then
+// valid UTF-8 and BOM-less UTF-16 are recognized, and finally the legacy
+// code page whose decoding looks the most like natural text is returned.
+func Detect(b []byte) Encoding {
+	switch {
+	case bytes.HasPrefix(b, bomUTF8):
+		return UTF8
+	case bytes.HasPrefix(b, bomUTF16LE):
+		return UTF16LE
+	case bytes.HasPrefix(b, bomUTF16BE):
+		return UTF16BE
+	}
+
+	if enc, ok := detectUTF16(b); ok {
+		return enc
+	}
+	if utf8.Valid(b) {
+		return UTF8
+	}
+
+	best := candidates[0]
+	bestScore, found := 0, false
+	for _, enc := range candidates {
+		s, err := Decode(b, enc)
+		if err != nil {
+			continue
+		}
+		score := textScore(s)
+		if !found || score > bestScore {
+			best, bestScore, found = enc, score, true
+		}
+	}
+	return best
+}
+
+// detectUTF16 recognizes BOM-less UTF-16 from the zero bytes that ASCII
+// characters leave on one side of each code unit.
+func detectUTF16(b []byte) (Encoding, bool) {
+	if len(b) < 4 || len(b)%2 != 0 {
+		return "", false
+	}
+
+	var evenZeros, oddZeros int
+	for i := 0; i+1 < len(b); i += 2 {
+		if b[i] == 0 {
+			evenZeros++
+		}
+		if b[i+1] == 0 {
+			oddZeros++
+		}
+	}
+
+	units := len(b) / 2
+	switch {
+	case oddZeros*10 > units*4 && evenZeros*10 < units:
+		return UTF16LE, true
+	case evenZeros*10 > units*4 && oddZeros*10 < units:
+		return UTF16BE, true
+	}
+	return "", false
+}
+
+// frequentLetters are accented letters common in the Western and Central
+// European languages covered by the candidates. They are rewarded on top of
+// other letters to tell apart code pages that map the same byte to letters
+// of different languages.
+const frequentLetters = "àâäçéèêëîïôöùûüßñáíóúãõœ" + "čďěňřšťůýžłąęśćńźżőű"
+
+// textScore rates how much s looks like natural text. Letters are rewarded,
+// while control characters, unusual symbols, words mixing Latin and Cyrillic
+// letters, runs of accented Latin letters and lowercase-to-uppercase switches
+// inside words are penalized.
+func textScore(s string) int {
+	score := 0
+
+	var prev rune
+	var latin, cyrillic bool
+	for _, r := range s {
+		if r >= 0x80 {
+			switch {
+			case unicode.IsLetter(r):
+				score += 2
+				if strings.ContainsRune(frequentLetters, unicode.ToLower(r)) {
+					score++
+				}
+			case r <= 0x9F:
+				score -= 20
+			case unicode.IsPunct(r) || r == 0xA0:
+			default:
+				score -= 2
+			}
+		}
+
+		if unicode.IsLetter(r) {
+			if unicode.Is(unicode.Cyrillic, r) {
+				cyrillic = true
+			} else if unicode.Is(unicode.Latin, r) {
+				latin = true
+			}
+			if unicode.IsLower(prev) && unicode.IsUpper(r) {
+				score -= 3
+			}
+			// Latin languages seldom put two accented letters in a row,
+			// unlike Cyrillic text decoded with a Latin code page.
+			if r >= 0x80 && prev >= 0x80 && unicode.Is(unicode.Latin, r) && unicode.Is(unicode.Latin, prev) {
+				score -= 4
+			}
+		} else {
+			if latin && cyrillic {
+				score -= 10
+			}
+			latin, cyrillic = false, false
+		}
+		prev = r
+	}
+	if latin && cyrillic {
+		score -= 10
+	}
+
+	return score
+}
@@ -0,0 +1,47 @@
+package charset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	mustEncode := func(s string, enc Encoding) []byte {
+		b, err := Encode(s, enc)
+		if err != nil {
+			t.Fatalf("encode %q to %s: %v", s, enc, err)
+		}
+		return b
+	}
+
+	french := "1\n00:00:01,000 --> 00:00:02,000\nLes élèves sont déjà à l'école, ça va être génial.\n"
+	german := "1\n00:00:01,000 --> 00:00:02,000\nDie Straße ist schön, aber wir müssen gehen.\n"
+	czech := "1\n00:00:01,000 --> 00:00:02,000\nPřijďte zítra, řekl mu, že to ještě není hotové.\n"
+	russian := "1\n00:00:01,000 --> 00:00:02,000\nПривет, как дела? Всё хорошо, спасибо.\n"
+
+	tests := []struct {
+		name     string
+		input    []byte
+		expected Encoding
+	}{
+		{"utf8_bom", append([]byte{0xEF, 0xBB, 0xBF}, french...), UTF8},
+		{"utf8", []byte(french), UTF8},
+		{"ascii", []byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n"), UTF8},
+		{"utf16le_bom", append([]byte{0xFF, 0xFE}, mustEncode(french, UTF16LE)...), UTF16LE},
+		{"utf16be_bom", append([]byte{0xFE, 0xFF}, mustEncode(french, UTF16BE)...), UTF16BE},
+		{"utf16le", mustEncode(french, UTF16LE), UTF16LE},
+		{"utf16be", mustEncode(russian, UTF16BE), UTF16BE},
+		{"french_windows1252", mustEncode(french, Windows1252), Windows1252},
+		{"german_windows1252", mustEncode(german, Windows1252), Windows1252},
+		{"czech_windows1250", mustEncode(czech, Windows1250), Windows1250},
+		{"russian_windows1251", mustEncode(russian, Windows1251), Windows1251},
+		{"russian_koi8r", mustEncode(russian, KOI8R), KOI8R},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Detect(tt.input))
+		})
+	}
+}
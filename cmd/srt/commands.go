@@ -0,0 +1,580 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/florentsorel/srt/fix"
+	"github.com/florentsorel/srt/lint"
+	"github.com/florentsorel/srt/model"
+)
+
+// errFlags is returned when the flags of a command cannot be parsed. The flag
+// set has already reported the problem.
+var errFlags = errors.New("invalid flags")
+
+// newFlagSet returns the flag set of a command, printing its usage to the
+// standard error of e.
+func newFlagSet(name, args string, e env) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "Usage: srt %s [flags] %s\n\nFlags:\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args with fs.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errFlags
+	}
+	return nil
+}
+
+// singleInput returns the only file argument of fs, or "-" for standard
+// input.
+func singleInput(fs *flag.FlagSet) (string, error) {
+	switch fs.NArg() {
+	case 0:
+		return "-", nil
+	case 1:
+		return fs.Arg(0), nil
+	}
+	return "", fmt.Errorf("expected one input file, got %d", fs.NArg())
+}
+
+// parseTime parses a time given as a timestamp ("01:02:03,456",
+// "01:02:03.456" or "02:03.456") or as a Go duration ("1.5s", "-200ms").
+func parseTime(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	value := s
+	sign := time.Duration(1)
+	if strings.HasPrefix(value, "-") {
+		sign, value = -1, value[1:]
+	}
+
+	value = strings.Replace(value, ",", ".", 1)
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil || seconds < 0 || seconds >= 60 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	d := time.Duration(math.Round(seconds*1000)) * time.Millisecond
+
+	unit := time.Minute
+	for i := len(parts) - 2; i >= 0; i-- {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 || (unit == time.Minute && n >= 60) {
+			return 0, fmt.Errorf("invalid time %q", s)
+		}
+		d += time.Duration(n) * unit
+		unit = time.Hour
+	}
+
+	return sign * d, nil
+}
+
+// durationFlag is a flag.Value holding a time parsed by parseTime.
+type durationFlag struct {
+	d   time.Duration
+	set bool
+}
+
+func (f *durationFlag) String() string {
+	if !f.set {
+		return ""
+	}
+	return f.d.String()
+}
+
+func (f *durationFlag) Set(s string) error {
+	d, err := parseTime(s)
+	if err != nil {
+		return err
+	}
+	f.d, f.set = d, true
+	return nil
+}
+
+// anchorList is a flag.Value collecting "CUE=TIME" anchors, CUE being the
+// 1-based position of a cue.
+type anchorList []model.Anchor
+
+func (l *anchorList) String() string {
+	parts := make([]string, len(*l))
+	for i, a := range *l {
+		parts[i] = fmt.Sprintf("%d=%s", a.CueIndex+1, a.CorrectStart.SRTString())
+	}
+	return strings.Join(parts, " ")
+}
+
+func (l *anchorList) Set(s string) error {
+	cue, at, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("invalid anchor %q, expected CUE=TIME", s)
+	}
+
+	n, err := strconv.Atoi(cue)
+	if err != nil || n < 1 {
+		return fmt.Errorf("invalid cue number %q", cue)
+	}
+	d, err := parseTime(at)
+	if err != nil {
+		return err
+	}
+
+	*l = append(*l, model.Anchor{CueIndex: n - 1, CorrectStart: model.Duration(d)})
+	return nil
+}
+
+func runShift(args []string, e env) error {
+	fs := newFlagSet("shift", "[file]", e)
+	var out outputFlags
+	out.register(fs)
+	var by durationFlag
+	fs.Var(&by, "by", "`offset` to add, as a duration (1.5s, -200ms) or a timestamp (-00:00:01,500)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if !by.set {
+		return errors.New("-by is required")
+	}
+
+	input, err := singleInput(fs)
+	if err != nil {
+		return err
+	}
+	s, err := out.read(input, e)
+	if err != nil {
+		return err
+	}
+
+	return out.write(input, s.Shift(by.d), e)
+}
+
+func runResync(args []string, e env) error {
+	fs := newFlagSet("resync", "[file]", e)
+	var out outputFlags
+	out.register(fs)
+	var anchors anchorList
+	fs.Var(&anchors, "anchor", "`CUE=TIME` anchor moving cue number CUE to start at TIME, repeatable")
+	piecewise := fs.String("piecewise", "", "resync each segment between anchors, `mode` linear or constant")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	input, err := singleInput(fs)
+	if err != nil {
+		return err
+	}
+	s, err := out.read(input, e)
+	if err != nil {
+		return err
+	}
+
+	var resynced model.Subtitles
+	switch *piecewise {
+	case "":
+		resynced, err = s.ResyncFit(anchors)
+	case "linear":
+		resynced, _, err = s.ResyncAnchors(anchors, model.PiecewiseLinear)
+	case "constant":
+		resynced, _, err = s.ResyncAnchors(anchors, model.PiecewiseConstant)
+	default:
+		return fmt.Errorf("unknown piecewise mode %q", *piecewise)
+	}
+	if err != nil {
+		return err
+	}
+
+	return out.write(input, resynced, e)
+}
+
+func runFPS(args []string, e env) error {
+	fs := newFlagSet("fps", "[file]", e)
+	var out outputFlags
+	out.register(fs)
+	from := fs.Float64("from-fps", 0, "frame `rate` the timings were made for, such as 23.976")
+	to := fs.Float64("to-fps", 0, "frame `rate` of the target video, such as 25")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *from <= 0 || *to <= 0 {
+		return errors.New("-from-fps and -to-fps are required")
+	}
+
+	input, err := singleInput(fs)
+	if err != nil {
+		return err
+	}
+	s, err := out.read(input, e)
+	if err != nil {
+		return err
+	}
+
+	return out.write(input, s.ConvertFrameRate(*from, *to), e)
+}
+
+func runConvert(args []string, e env) error {
+	fs := newFlagSet("convert", "[file]", e)
+	var out outputFlags
+	out.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if out.inPlace {
+		return errors.New("-i cannot be used to convert")
+	}
+	if out.output == "" && out.to == "" {
+		return errors.New("-o or -to is required")
+	}
+
+	input, err := singleInput(fs)
+	if err != nil {
+		return err
+	}
+	s, err := out.read(input, e)
+	if err != nil {
+		return err
+	}
+
+	return out.write(input, s, e)
+}
+
+func runValidate(args []string, e env) error {
+	fs := newFlagSet("validate", "[file...]", e)
+	var in inputFlags
+	in.register(fs)
+	var cfg lint.Config
+	fs.DurationVar(&cfg.MinGap, "min-gap", 0, "minimum `duration` between cues")
+	fs.DurationVar(&cfg.MinDuration, "min-duration", 0, "minimum `duration` of a cue")
+	fs.DurationVar(&cfg.MaxDuration, "max-duration", 0, "maximum `duration` of a cue")
+	fs.IntVar(&cfg.MaxLines, "max-lines", 0, "maximum `number` of lines of a cue")
+	fs.IntVar(&cfg.MaxLineLength, "max-line-length", 0, "maximum `width` of a line, in columns")
+	strict := fs.Bool("strict", false, "fail on warnings too")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	inputs := fs.Args()
+	if len(inputs) == 0 {
+		inputs = []string{"-"}
+	}
+
+	var errs, warnings int
+	for _, input := range inputs {
+		s, err := in.read(input, e)
+		if err != nil {
+			return err
+		}
+
+		name := input
+		if isStdio(input) {
+			name = "<stdin>"
+		}
+		for _, f := range lint.Run(s, lint.Rules(cfg)...) {
+			fmt.Fprintf(e.stdout, "%s: cue %d: %s: %s (%s)\n", name, f.Cue, f.Severity, f.Message, f.Rule)
+			switch f.Severity {
+			case lint.Error:
+				errs++
+			case lint.Warning:
+				warnings++
+			}
+		}
+	}
+
+	if errs > 0 || (*strict && warnings > 0) {
+		return &exitError{code: exitValidation, err: fmt.Errorf("%w: %d errors, %d warnings", errValidation, errs, warnings)}
+	}
+	return nil
+}
+
+func runRenumber(args []string, e env) error {
+	fs := newFlagSet("renumber", "[file]", e)
+	var out outputFlags
+	out.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	input, err := singleInput(fs)
+	if err != nil {
+		return err
+	}
+	s, err := out.read(input, e)
+	if err != nil {
+		return err
+	}
+
+	return out.write(input, s.Renumber(), e)
+}
+
+func runMerge(args []string, e env) error {
+	fs := newFlagSet("merge", "file...", e)
+	var out outputFlags
+	out.register(fs)
+	overlap := fs.String("overlap", "keep", "`policy` for overlapping cues: keep, stack or top")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if out.inPlace {
+		return errors.New("-i cannot be used to merge")
+	}
+
+	var opts model.MergeOptions
+	switch *overlap {
+	case "keep":
+		opts.Overlap = model.MergeKeepBoth
+	case "stack":
+		opts.Overlap = model.MergeStack
+	case "top":
+		opts.Overlap = model.MergeTop
+	default:
+		return fmt.Errorf("unknown overlap policy %q", *overlap)
+	}
+	if fs.NArg() == 0 {
+		return errors.New("expected at least one input file")
+	}
+
+	var merged model.Subtitles
+	for _, input := range fs.Args() {
+		s, err := out.read(input, e)
+		if err != nil {
+			return err
+		}
+		merged = model.Merge(merged, s, opts)
+	}
+
+	return out.write(fs.Arg(0), merged, e)
+}
+
+func runSplit(args []string, e env) error {
+	fs := newFlagSet("split", "[file]", e)
+	var in inputFlags
+	in.register(fs)
+	var at durationFlag
+	fs.Var(&at, "at", "`time` at which the second part starts")
+	every := fs.Int("every", 0, "split into parts of `n` cues instead of at a time")
+	straddle := fs.String("straddle", "assign", "`policy` for cues displayed across -at: assign, clip or duplicate")
+	rebase := fs.Bool("rebase", false, "shift the second part so that -at becomes 0")
+	output := fs.String("o", "", "`prefix` of the output files, from the input file by default")
+	to := fs.String("to", "", "output `format` (srt, vtt or ass), from the input by default")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if at.set == (*every > 0) {
+		return errors.New("one of -at or -every is required")
+	}
+	if err := checkFormat(*to); err != nil {
+		return err
+	}
+
+	opts := model.SplitOptions{KeepTimes: !*rebase}
+	switch *straddle {
+	case "assign":
+		opts.Straddle = model.StraddleAssign
+	case "clip":
+		opts.Straddle = model.StraddleClip
+	case "duplicate":
+		opts.Straddle = model.StraddleDuplicate
+	default:
+		return fmt.Errorf("unknown straddle policy %q", *straddle)
+	}
+
+	input, err := singleInput(fs)
+	if err != nil {
+		return err
+	}
+	prefix := *output
+	if prefix == "" {
+		if isStdio(input) {
+			return errors.New("-o is required when reading standard input")
+		}
+		prefix = strings.TrimSuffix(input, filepath.Ext(input))
+	}
+
+	s, err := in.read(input, e)
+	if err != nil {
+		return err
+	}
+
+	var parts []model.Subtitles
+	if *every > 0 {
+		parts = s.SplitEvery(*every)
+	} else {
+		first, second := s.SplitAt(model.Duration(at.d), opts)
+		parts = []model.Subtitles{first, second}
+	}
+
+	format := *to
+	if format == "" {
+		format = formatOf(input, formatSRT)
+	}
+	for i, part := range parts {
+		path := fmt.Sprintf("%s-%d.%s", prefix, i+1, format)
+		if err := writeFile(path, part, format, 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stdout, "%s: %d cues\n", path, len(part.Items))
+	}
+	return nil
+}
+
+func runStripTags(args []string, e env) error {
+	fs := newFlagSet("strip-tags", "[file]", e)
+	var out outputFlags
+	out.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	input, err := singleInput(fs)
+	if err != nil {
+		return err
+	}
+	s, err := out.read(input, e)
+	if err != nil {
+		return err
+	}
+
+	stripped := model.NewPipeline().
+		Map(func(cue model.Cue) model.Cue {
+			cue.Text = strings.TrimSpace(cue.PlainText())
+			return cue
+		}).
+		Filter(func(cue model.Cue) bool { return cue.Text != "" }).
+		Renumber().
+		Apply(s)
+
+	return out.write(input, stripped, e)
+}
+
+func runFix(args []string, e env) error {
+	fs := newFlagSet("fix", "[file]", e)
+	var out outputFlags
+	out.register(fs)
+	lang := fs.String("lang", "en", "`language` of the rules (en, fr or de)")
+	dict := fs.String("dict", "", "dictionary `file` of \"wrong=right\" replacements")
+	dryRun := fs.Bool("dry-run", false, "print the changes instead of writing the result")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	rules, err := fix.ForLanguage(*lang)
+	if err != nil {
+		return err
+	}
+	if *dict != "" {
+		file, err := os.Open(*dict)
+		if err != nil {
+			return err
+		}
+		replacements, err := fix.ReadDictionary(file)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", *dict, err)
+		}
+		rules = append(rules, fix.Dictionary(replacements))
+	}
+
+	input, err := singleInput(fs)
+	if err != nil {
+		return err
+	}
+	s, err := out.read(input, e)
+	if err != nil {
+		return err
+	}
+
+	fixed, changes := fix.Apply(s, rules...)
+	if *dryRun {
+		return fix.Diff(e.stdout, changes)
+	}
+	return out.write(input, fixed, e)
+}
+
+func runStats(args []string, e env) error {
+	fs := newFlagSet("stats", "[file]", e)
+	var in inputFlags
+	in.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	input, err := singleInput(fs)
+	if err != nil {
+		return err
+	}
+	s, err := in.read(input, e)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(e.stdout, "cues: %d\n", len(s.Items))
+	if len(s.Items) == 0 {
+		return nil
+	}
+
+	first, last := s.Items[0].Start, s.Items[0].End
+	var chars, lines, maxLines, maxLineLength int
+	var displayed time.Duration
+	maxCPS, maxCPSCue := 0.0, 0
+	for i, cue := range s.Items {
+		if cue.Start < first {
+			first = cue.Start
+		}
+		if cue.End > last {
+			last = cue.End
+		}
+		if d := time.Duration(cue.End - cue.Start); d > 0 {
+			displayed += d
+		}
+
+		chars += cue.VisibleCharCount()
+		cueLines := strings.Split(cue.PlainText(), "\n")
+		lines += len(cueLines)
+		if len(cueLines) > maxLines {
+			maxLines = len(cueLines)
+		}
+		for _, line := range cueLines {
+			if n := model.TextWidth(line); n > maxLineLength {
+				maxLineLength = n
+			}
+		}
+
+		if cps := cue.CPS(); cps > maxCPS {
+			maxCPS, maxCPSCue = cps, i+1
+		}
+	}
+
+	fmt.Fprintf(e.stdout, "first start: %s\n", first.SRTString())
+	fmt.Fprintf(e.stdout, "last end: %s\n", last.SRTString())
+	fmt.Fprintf(e.stdout, "displayed: %s\n", model.Duration(displayed).SRTString())
+	fmt.Fprintf(e.stdout, "characters: %d\n", chars)
+	fmt.Fprintf(e.stdout, "lines: %d (max %d per cue)\n", lines, maxLines)
+	fmt.Fprintf(e.stdout, "max line length: %d\n", maxLineLength)
+	if displayed > 0 {
+		fmt.Fprintf(e.stdout, "average cps: %.2f\n", float64(chars)/displayed.Seconds())
+	}
+	fmt.Fprintf(e.stdout, "max cps: %.2f (cue %d)\n", maxCPS, maxCPSCue)
+	return nil
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		err      bool
+	}{
+		{"1.5s", 1500 * time.Millisecond, false},
+		{"-200ms", -200 * time.Millisecond, false},
+		{"01:02:03,456", time.Hour + 2*time.Minute + 3456*time.Millisecond, false},
+		{"01:02:03.456", time.Hour + 2*time.Minute + 3456*time.Millisecond, false},
+		{"02:03.5", 2*time.Minute + 3500*time.Millisecond, false},
+		{"-00:00:01,500", -1500 * time.Millisecond, false},
+		{"00:60:00,000", 0, true},
+		{"12", 0, true},
+		{"a:b:c", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := parseTime(tt.input)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+}
+
+func TestCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		stdin    string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "shift",
+			stdin:    sample,
+			args:     []string{"shift", "-by", "-00:00:00,500"},
+			expected: "1\n00:00:00,500 --> 00:00:01,500\n<i>Hello</i>\n\n2\n00:00:02,500 --> 00:00:04,000\nWorld\n",
+		},
+		{
+			name:     "resync with two anchors",
+			stdin:    sample,
+			args:     []string{"resync", "-anchor", "1=00:00:02,000", "-anchor", "2=00:00:06,000"},
+			expected: "1\n00:00:02,000 --> 00:00:04,000\n<i>Hello</i>\n\n2\n00:00:06,000 --> 00:00:09,000\nWorld\n",
+		},
+		{
+			name:     "resync piecewise",
+			stdin:    sample,
+			args:     []string{"resync", "-piecewise", "constant", "-anchor", "1=2s", "-anchor", "2=3s"},
+			expected: "1\n00:00:02,000 --> 00:00:03,000\n<i>Hello</i>\n\n2\n00:00:03,000 --> 00:00:04,500\nWorld\n",
+		},
+		{
+			name:     "fps",
+			stdin:    sample,
+			args:     []string{"fps", "-from-fps", "25", "-to-fps", "50"},
+			expected: "1\n00:00:00,500 --> 00:00:01,000\n<i>Hello</i>\n\n2\n00:00:01,500 --> 00:00:02,250\nWorld\n",
+		},
+		{
+			name:     "convert",
+			stdin:    sample,
+			args:     []string{"convert", "-to", "vtt"},
+			expected: "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.000\n<i>Hello</i>\n\n2\n00:00:03.000 --> 00:00:04.500\nWorld\n",
+		},
+		{
+			name:     "renumber",
+			stdin:    "7\n00:00:01,000 --> 00:00:02,000\nHello\n\n9\n00:00:03,000 --> 00:00:04,000\nWorld\n",
+			args:     []string{"renumber"},
+			expected: "1\n00:00:01,000 --> 00:00:02,000\nHello\n\n2\n00:00:03,000 --> 00:00:04,000\nWorld\n",
+		},
+		{
+			name:     "strip tags",
+			stdin:    "1\n00:00:01,000 --> 00:00:02,000\n{\\an8}<i>Hello</i>\n\n2\n00:00:03,000 --> 00:00:04,000\n<b> </b>\n\n3\n00:00:05,000 --> 00:00:06,000\n<font color=\"red\">World</font>\n",
+			args:     []string{"strip-tags"},
+			expected: "1\n00:00:01,000 --> 00:00:02,000\nHello\n\n2\n00:00:05,000 --> 00:00:06,000\nWorld\n",
+		},
+		{
+			name:     "validate",
+			stdin:    "1\n00:00:01,000 --> 00:00:02,000\nHello\n\n3\n00:00:03,000 --> 00:00:04,000\nWorld\n",
+			args:     []string{"validate", "-max-line-length", "4"},
+			expected: "<stdin>: cue 1: warning: line 1 is 5 columns wide, above 4 (max-line-length)\n<stdin>: cue 2: warning: index 3, expected 2 (index-sequence)\n<stdin>: cue 2: warning: line 1 is 5 columns wide, above 4 (max-line-length)\n",
+		},
+		{
+			name:  "stats",
+			stdin: sample,
+			args:  []string{"stats"},
+			expected: "cues: 2\n" +
+				"first start: 00:00:01,000\n" +
+				"last end: 00:00:04,500\n" +
+				"displayed: 00:00:02,500\n" +
+				"characters: 10\n" +
+				"lines: 2 (max 1 per cue)\n" +
+				"max line length: 5\n" +
+				"average cps: 4.00\n" +
+				"max cps: 5.00 (cue 1)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := runCLI(tt.stdin, tt.args...)
+			assert.Equal(t, exitOK, code, stderr)
+			assert.Equal(t, tt.expected, stdout)
+		})
+	}
+}
+
+func TestValidateStrict(t *testing.T) {
+	input := "3\n00:00:01,000 --> 00:00:02,000\nHello\n"
+
+	code, _, _ := runCLI(input, "validate")
+	assert.Equal(t, exitOK, code)
+
+	code, _, stderr := runCLI(input, "validate", "-strict")
+	assert.Equal(t, exitValidation, code)
+	assert.Contains(t, stderr, "validation failed: 0 errors, 1 warnings")
+}
+
+func TestMerge(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.srt")
+	b := filepath.Join(dir, "b.vtt")
+	assert.NoError(t, os.WriteFile(a, []byte("1\n00:00:03,000 --> 00:00:04,000\nSecond\n"), 0o644))
+	assert.NoError(t, os.WriteFile(b, []byte("WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nFirst\n"), 0o644))
+
+	code, stdout, stderr := runCLI("", "merge", a, b)
+	assert.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "1\n00:00:01,000 --> 00:00:02,000\nFirst\n\n2\n00:00:03,000 --> 00:00:04,000\nSecond\n", stdout)
+
+	signs := filepath.Join(dir, "signs.srt")
+	assert.NoError(t, os.WriteFile(signs, []byte("1\n00:00:03,500 --> 00:00:05,000\nEXIT\n"), 0o644))
+
+	code, stdout, stderr = runCLI("", "merge", "-overlap", "stack", a, signs)
+	assert.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "1\n00:00:03,000 --> 00:00:03,500\nSecond\n\n2\n00:00:03,500 --> 00:00:04,000\nSecond\nEXIT\n\n3\n00:00:04,000 --> 00:00:05,000\nEXIT\n", stdout)
+
+	code, _, stderr = runCLI("", "merge", "-overlap", "other", a, signs)
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, `unknown overlap policy "other"`)
+}
+
+func TestSplit(t *testing.T) {
+	path := writeTemp(t, "movie.srt", sample)
+	prefix := filepath.Join(filepath.Dir(path), "movie")
+
+	code, stdout, stderr := runCLI("", "split", "-at", "2.5s", "-rebase", path)
+	assert.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, prefix+"-1.srt: 1 cues\n"+prefix+"-2.srt: 1 cues\n", stdout)
+
+	first, err := os.ReadFile(prefix + "-1.srt")
+	assert.NoError(t, err)
+	assert.Equal(t, "1\n00:00:01,000 --> 00:00:02,000\n<i>Hello</i>\n", string(first))
+
+	second, err := os.ReadFile(prefix + "-2.srt")
+	assert.NoError(t, err)
+	assert.Equal(t, "1\n00:00:00,500 --> 00:00:02,000\nWorld\n", string(second))
+
+	code, _, stderr = runCLI(sample, "split", "-at", "2.5s")
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, "-o is required")
+
+	code, _, stderr = runCLI(sample, "split", "-o", prefix)
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, "one of -at or -every is required")
+}
+
+func TestSplitStraddle(t *testing.T) {
+	path := writeTemp(t, "movie.srt", sample)
+	prefix := filepath.Join(filepath.Dir(path), "movie")
+
+	code, _, stderr := runCLI("", "split", "-at", "1.5s", "-straddle", "clip", path)
+	assert.Equal(t, exitOK, code, stderr)
+
+	first, err := os.ReadFile(prefix + "-1.srt")
+	assert.NoError(t, err)
+	assert.Equal(t, "1\n00:00:01,000 --> 00:00:01,500\n<i>Hello</i>\n", string(first))
+
+	second, err := os.ReadFile(prefix + "-2.srt")
+	assert.NoError(t, err)
+	assert.Equal(t, "1\n00:00:01,500 --> 00:00:02,000\n<i>Hello</i>\n\n2\n00:00:03,000 --> 00:00:04,500\nWorld\n", string(second))
+}
+
+func TestSplitEvery(t *testing.T) {
+	path := writeTemp(t, "movie.srt", sample)
+	prefix := filepath.Join(filepath.Dir(path), "movie")
+
+	code, stdout, stderr := runCLI("", "split", "-every", "1", "-to", "vtt", path)
+	assert.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, prefix+"-1.vtt: 1 cues\n"+prefix+"-2.vtt: 1 cues\n", stdout)
+
+	second, err := os.ReadFile(prefix + "-2.vtt")
+	assert.NoError(t, err)
+	assert.Contains(t, string(second), "00:00:03.000 --> 00:00:04.500\nWorld")
+}
+
+func TestFix(t *testing.T) {
+	input := "1\n00:00:01,000 --> 00:00:02,000\n<i>l think</i>  so .\n\n2\n00:00:03,000 --> 00:00:04,500\nTeh end\n"
+
+	code, stdout, stderr := runCLI(input, "fix")
+	assert.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "1\n00:00:01,000 --> 00:00:02,000\n<i>I think</i> so.\n\n2\n00:00:03,000 --> 00:00:04,500\nTeh end\n", stdout)
+
+	dict := writeTemp(t, "ocr.txt", "Teh=The\n")
+	code, stdout, stderr = runCLI(input, "fix", "-dry-run", "-dict", dict)
+	assert.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "cue 1\n-<i>l think</i>  so .\n+<i>I think</i> so.\ncue 2\n-Teh end\n+The end\n", stdout)
+
+	code, _, stderr = runCLI(input, "fix", "-lang", "xx")
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, `unknown language "xx"`)
+
+	broken := writeTemp(t, "broken.txt", "broken\n")
+	code, _, stderr = runCLI(input, "fix", "-dict", broken)
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, "line 1: invalid dictionary entry")
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/florentsorel/srt"
+	"github.com/florentsorel/srt/ass"
+	"github.com/florentsorel/srt/model"
+	"github.com/florentsorel/srt/vtt"
+)
+
+// Subtitle file formats.
+const (
+	formatSRT = "srt"
+	formatVTT = "vtt"
+	formatASS = "ass"
+)
+
+// formatOf returns the format matching the extension of path, or def when
+// the extension is unknown.
+func formatOf(path, def string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".srt":
+		return formatSRT
+	case ".vtt":
+		return formatVTT
+	case ".ass", ".ssa":
+		return formatASS
+	}
+	return def
+}
+
+// checkFormat returns an error if f is set and is not a known format.
+func checkFormat(f string) error {
+	switch f {
+	case "", formatSRT, formatVTT, formatASS:
+		return nil
+	}
+	return fmt.Errorf("unknown format %q", f)
+}
+
+// isStdio reports whether path designates standard input or output.
+func isStdio(path string) bool {
+	return path == "" || path == "-"
+}
+
+// inputFlags are the flags of the commands reading subtitle files.
+type inputFlags struct {
+	from string
+}
+
+func (f *inputFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.from, "from", "", "input `format` (srt, vtt or ass), from the extension by default")
+}
+
+// read parses the subtitle file at path, or standard input if path is empty
+// or "-". Parse errors exit with exitParse.
+func (f *inputFlags) read(path string, e env) (model.Subtitles, error) {
+	if err := checkFormat(f.from); err != nil {
+		return model.Subtitles{}, err
+	}
+
+	name := path
+	r := e.stdin
+	if isStdio(path) {
+		name = "<stdin>"
+	} else {
+		file, err := os.Open(path)
+		if err != nil {
+			return model.Subtitles{}, err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	format := f.from
+	if format == "" {
+		format = formatOf(path, formatSRT)
+	}
+
+	var s *model.Subtitles
+	var err error
+	switch format {
+	case formatVTT:
+		s, err = vtt.Parse(r)
+	case formatASS:
+		s, err = ass.Parse(r)
+	default:
+		var res *srt.Result
+		res, err = srt.ParseWithOptions(r, srt.Options{DetectEncoding: true})
+		if err == nil {
+			s = res.Subtitles
+		}
+	}
+	if err != nil {
+		return model.Subtitles{}, &exitError{code: exitParse, err: fmt.Errorf("%s: %w", name, err)}
+	}
+
+	return *s, nil
+}
+
+// outputFlags are the flags of the commands writing a subtitle file.
+type outputFlags struct {
+	inputFlags
+	output  string
+	to      string
+	inPlace bool
+	backup  string
+}
+
+func (f *outputFlags) register(fs *flag.FlagSet) {
+	f.inputFlags.register(fs)
+	fs.StringVar(&f.output, "o", "", "write to `file` instead of standard output")
+	fs.StringVar(&f.to, "to", "", "output `format` (srt, vtt or ass), from the output file extension by default")
+	fs.BoolVar(&f.inPlace, "i", false, "modify the input file in place")
+	fs.StringVar(&f.backup, "backup", ".bak", "`suffix` of the backup made by -i, none if empty")
+}
+
+// write writes s, read from input, where the flags tell.
+func (f *outputFlags) write(input string, s model.Subtitles, e env) error {
+	if err := checkFormat(f.to); err != nil {
+		return err
+	}
+
+	if f.inPlace {
+		if isStdio(input) {
+			return errors.New("-i needs an input file")
+		}
+		if f.output != "" {
+			return errors.New("-i and -o cannot be used together")
+		}
+		return f.writeInPlace(input, s)
+	}
+
+	format := f.to
+	if format == "" {
+		format = formatOf(f.output, formatOf(input, formatSRT))
+	}
+	if isStdio(f.output) {
+		return writeTrack(e.stdout, s, format)
+	}
+	return writeFile(f.output, s, format, 0o644)
+}
+
+// writeInPlace replaces the file at path with s, in the format of the file,
+// after copying it to its backup.
+func (f *outputFlags) writeInPlace(path string, s model.Subtitles) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if f.backup != "" {
+		original, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path+f.backup, original, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+
+	format := f.to
+	if format == "" {
+		format = formatOf(path, formatSRT)
+	}
+	return writeFile(path, s, format, info.Mode().Perm())
+}
+
+// writeFile writes s to the file at path. Nothing is written if s cannot be
+// serialized.
+func writeFile(path string, s model.Subtitles, format string, perm os.FileMode) error {
+	var b bytes.Buffer
+	if err := writeTrack(&b, s, format); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b.Bytes(), perm)
+}
+
+// writeTrack writes s to w in the given format.
+func writeTrack(w io.Writer, s model.Subtitles, format string) error {
+	var err error
+	switch format {
+	case formatVTT:
+		_, err = vtt.Write(w, s)
+	case formatASS:
+		_, err = ass.Write(w, s)
+	default:
+		_, err = srt.Write(w, s, srt.WriteOptions{TrailingNewline: true})
+	}
+	return err
+}
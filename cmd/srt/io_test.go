@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatOf(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"movie.srt", formatSRT},
+		{"movie.VTT", formatVTT},
+		{"movie.ass", formatASS},
+		{"movie.ssa", formatASS},
+		{"movie.txt", "default"},
+		{"-", "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatOf(tt.path, "default"))
+		})
+	}
+}
+
+func TestInPlace(t *testing.T) {
+	path := writeTemp(t, "movie.srt", sample)
+
+	code, stdout, _ := runCLI("", "shift", "-by", "1s", "-i", path)
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stdout)
+
+	shifted, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(shifted), "00:00:02,000 --> 00:00:03,000")
+
+	backup, err := os.ReadFile(path + ".bak")
+	assert.NoError(t, err)
+	assert.Equal(t, sample, string(backup))
+}
+
+func TestInPlaceWithoutBackup(t *testing.T) {
+	path := writeTemp(t, "movie.srt", sample)
+
+	code, _, _ := runCLI("", "renumber", "-i", "-backup", "", path)
+	assert.Equal(t, exitOK, code)
+
+	_, err := os.Stat(path + ".bak")
+	assert.True(t, os.IsNotExist(err), "Expected no backup file")
+}
+
+func TestInPlaceErrors(t *testing.T) {
+	code, _, stderr := runCLI(sample, "renumber", "-i")
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, "-i needs an input file")
+
+	path := writeTemp(t, "movie.srt", sample)
+	code, _, stderr = runCLI("", "renumber", "-i", "-o", filepath.Join(filepath.Dir(path), "out.srt"), path)
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, "-i and -o cannot be used together")
+}
+
+func TestOutputFile(t *testing.T) {
+	path := writeTemp(t, "movie.srt", sample)
+	output := filepath.Join(filepath.Dir(path), "movie.vtt")
+
+	code, _, _ := runCLI("", "convert", "-o", output, path)
+	assert.Equal(t, exitOK, code)
+
+	converted, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Contains(t, string(converted), "WEBVTT")
+	assert.Contains(t, string(converted), "00:00:01.000 --> 00:00:02.000")
+}
@@ -0,0 +1,131 @@
+// Command srt performs everyday operations on subtitle files: shifting,
+// resynchronizing, converting between formats, validating, and more.
+//
+// Usage:
+//
+//	srt <command> [flags] [file...]
+//
+// Files are read from the arguments, or from standard input when none or "-"
+// is given. Results are written to standard output, to the file given with
+// -o, or in place with -i. The format of each file is chosen from its
+// extension (.srt, .vtt, .ass or .ssa), SRT being the default.
+//
+// The exit code is 0 on success, 1 on usage or I/O errors, 2 when an input
+// cannot be parsed and 3 when validation fails.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Exit codes.
+const (
+	exitOK         = 0
+	exitFailure    = 1
+	exitParse      = 2
+	exitValidation = 3
+)
+
+// exitError is an error carrying the exit code it should produce.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// errValidation is returned by the validate command when the track fails.
+var errValidation = errors.New("validation failed")
+
+// env holds the standard streams of a command.
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// command is a subcommand of the tool.
+type command struct {
+	summary string
+	run     func(args []string, e env) error
+}
+
+var commands = map[string]command{
+	"shift":      {"shift all cues by a duration", runShift},
+	"resync":     {"resynchronize cues from anchor points", runResync},
+	"fps":        {"convert timings between frame rates", runFPS},
+	"convert":    {"convert between SRT, WebVTT and ASS", runConvert},
+	"validate":   {"check cues against timing and layout rules", runValidate},
+	"renumber":   {"number cues sequentially from 1", runRenumber},
+	"merge":      {"merge several files into one", runMerge},
+	"split":      {"split a file in two at a given time", runSplit},
+	"strip-tags": {"remove formatting tags", runStripTags},
+	"fix":        {"correct typography and OCR errors", runFix},
+	"stats":      {"print statistics about cues", runStats},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}))
+}
+
+// run executes the command line args and returns the exit code.
+func run(args []string, e env) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(e.stderr)
+		if len(args) == 0 {
+			return exitFailure
+		}
+		return exitOK
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(e.stderr, "srt: unknown command %q\n", args[0])
+		usage(e.stderr)
+		return exitFailure
+	}
+
+	err := cmd.run(args[1:], e)
+	if err == nil {
+		return exitOK
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if errors.Is(err, errFlags) {
+		return exitFailure
+	}
+
+	fmt.Fprintf(e.stderr, "srt %s: %v\n", args[0], err)
+
+	var exit *exitError
+	if errors.As(err, &exit) {
+		return exit.code
+	}
+	return exitFailure
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: srt <command> [flags] [file...]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-11s %s\n", name, commands[name].summary)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "srt <command> -h" for the flags of a command.`)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sample = "1\n00:00:01,000 --> 00:00:02,000\n<i>Hello</i>\n\n2\n00:00:03,000 --> 00:00:04,500\nWorld\n"
+
+// runCLI runs the command line args with stdin as standard input and returns
+// the exit code along with the standard output and error.
+func runCLI(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr strings.Builder
+	code := run(args, env{stdin: strings.NewReader(stdin), stdout: &stdout, stderr: &stderr})
+	return code, stdout.String(), stderr.String()
+}
+
+// writeTemp writes content to a file named name in a temporary directory and
+// returns its path.
+func writeTemp(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestRun_Usage(t *testing.T) {
+	code, _, stderr := runCLI("")
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, "Usage: srt <command>")
+
+	code, _, stderr = runCLI("", "help")
+	assert.Equal(t, exitOK, code)
+	assert.Contains(t, stderr, "strip-tags")
+
+	code, _, stderr = runCLI("", "unknown")
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, `unknown command "unknown"`)
+}
+
+func TestRun_ExitCodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		stdin string
+		args  []string
+		code  int
+	}{
+		{"success", sample, []string{"renumber"}, exitOK},
+		{"help", "", []string{"shift", "-h"}, exitOK},
+		{"unknown flag", sample, []string{"shift", "-unknown"}, exitFailure},
+		{"missing flag", sample, []string{"shift"}, exitFailure},
+		{"missing file", "", []string{"stats", filepath.Join(t.TempDir(), "missing.srt")}, exitFailure},
+		{"parse error", "not a subtitle", []string{"stats"}, exitParse},
+		{"validation failure", "1\n00:00:02,000 --> 00:00:01,000\nBackwards\n", []string{"validate"}, exitValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, _ := runCLI(tt.stdin, tt.args...)
+			assert.Equal(t, tt.code, code)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package srt
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/florentsorel/srt/internal/parser"
+	"github.com/florentsorel/srt/model"
+)
+
+// Decoder reads cues one at a time from an input stream. It splits the stream
+// into blocks at empty lines, where the lexer ends a cue, and parses each block
+// on its own as Parse would. Only the block being decoded is held in memory,
+// which makes it suitable for large files.
+type Decoder struct {
+	r      *bufio.Reader
+	line   int
+	offset int
+	cue    int
+	err    error
+}
+
+// NewDecoder returns a new Decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next cue from the input. It returns io.EOF when there are
+// no more cues. Empty lines between cues are ignored, while a line holding
+// only white space belongs to the cue around it, as it does for Parse. Parse
+// errors are returned as *ParseError with positions relative to the whole
+// stream; once an error has been returned, every subsequent call returns it
+// as well.
+func (d *Decoder) Decode() (model.Cue, error) {
+	if d.err != nil {
+		return model.Cue{}, d.err
+	}
+
+	for {
+		block, line, offset, err := d.readBlock()
+		if err != nil {
+			d.err = err
+			return model.Cue{}, err
+		}
+		d.cue++
+
+		p, err := parser.NewFromString(block)
+		if err != nil {
+			d.err = d.relocate(err, line, offset)
+			return model.Cue{}, d.err
+		}
+
+		cues, err := p.Parse()
+		if err != nil {
+			d.err = d.relocate(err, line, offset)
+			return model.Cue{}, d.err
+		}
+
+		// A block holding no cue is skipped like blank lines.
+		if len(cues) == 0 {
+			d.cue--
+			continue
+		}
+		return cues[0], nil
+	}
+}
+
+// readBlock reads the next non-empty block of lines, up to an empty line or
+// the end of the input. It returns the block with its first line number and
+// byte offset in the stream.
+func (d *Decoder) readBlock() (string, int, int, error) {
+	var b strings.Builder
+	startLine, startOffset := 0, 0
+
+	for {
+		s, err := d.r.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", 0, 0, err
+		}
+		if s == "" && err == io.EOF {
+			break
+		}
+
+		d.line++
+		lineOffset := d.offset
+		d.offset += len(s)
+
+		if strings.TrimRight(s, "\r\n") == "" {
+			if b.Len() > 0 {
+				break
+			}
+			if err == io.EOF {
+				break
+			}
+			continue
+		}
+
+		// Lines are kept as read, so that offsets within the block match
+		// the stream.
+		if b.Len() == 0 {
+			startLine, startOffset = d.line, lineOffset
+		}
+		b.WriteString(s)
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	if b.Len() == 0 {
+		return "", 0, 0, io.EOF
+	}
+	return b.String(), startLine, startOffset, nil
+}
+
+// relocate rewrites the position of a parse error found in a block starting
+// at the given line and byte offset so that it is relative to the stream.
+func (d *Decoder) relocate(err error, line, offset int) error {
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		return err
+	}
+
+	relocated := *parseErr
+	relocated.Line += line - 1
+	relocated.Offset += offset
+	relocated.Cue = d.cue
+	if relocated.Actual.Line > 0 {
+		relocated.Actual.Line += line - 1
+		relocated.Actual.Offset += offset
+	}
+	return &relocated
+}
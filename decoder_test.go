@@ -0,0 +1,118 @@
+package srt
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/florentsorel/srt/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	input := "1\r\n00:00:01,000 --> 00:00:02,000\r\nFirst\r\nline\r\n\r\n\r\n2\r\n00:00:03,000 --> 00:00:04,500\r\nSecond\r\n"
+	d := NewDecoder(strings.NewReader(input))
+
+	cue, err := d.Decode()
+	assert.NoError(t, err, "Expected no error decoding first cue")
+	assert.Equal(t, model.Cue{Index: 1, Start: model.Duration(time.Second), End: model.Duration(2 * time.Second), Text: "First\nline"}, cue)
+
+	cue, err = d.Decode()
+	assert.NoError(t, err, "Expected no error decoding second cue")
+	assert.Equal(t, model.Cue{Index: 2, Start: model.Duration(3 * time.Second), End: model.Duration(4*time.Second + 500*time.Millisecond), Text: "Second"}, cue)
+
+	_, err = d.Decode()
+	assert.Equal(t, io.EOF, err, "Expected io.EOF at end of input, got %v", err)
+	_, err = d.Decode()
+	assert.Equal(t, io.EOF, err, "Expected io.EOF to be sticky, got %v", err)
+}
+
+func TestDecoder_DecodeEmpty(t *testing.T) {
+	d := NewDecoder(strings.NewReader("\n\n"))
+	_, err := d.Decode()
+	assert.Equal(t, io.EOF, err, "Expected io.EOF on empty input, got %v", err)
+}
+
+func TestDecoder_DecodeWhitespaceLines(t *testing.T) {
+	for _, input := range []string{
+		"1\n00:00:01,000 --> 00:00:02,000\nHi\n \nthere\n\n2\n00:00:03,000 --> 00:00:04,000\nBye\n",
+		"1\n00:00:01,000 --> 00:00:02,000\nHi\n \r\n2\n00:00:03,000 --> 00:00:04,000\nBye\n",
+		"1\n00:00:01,000 --> 00:00:02,000\nHi\n\n   \n",
+	} {
+		expected, expectedErr := Parse(strings.NewReader(input))
+
+		var cues []model.Cue
+		d := NewDecoder(strings.NewReader(input))
+		cue, err := d.Decode()
+		for err == nil {
+			cues = append(cues, cue)
+			cue, err = d.Decode()
+		}
+
+		if expectedErr != nil {
+			assert.Equal(t, expectedErr, err, "Expected the same error as Parse for %q", input)
+			continue
+		}
+		assert.Equal(t, io.EOF, err, "Expected io.EOF at end of %q, got %v", input, err)
+		assert.Equal(t, expected.Items, cues, "Expected the same cues as Parse for %q", input)
+	}
+}
+
+func TestDecoder_DecodeError(t *testing.T) {
+	input := "1\n00:00:01,000 --> 00:00:02,000\nFirst\n\n2\n00:00:03,000 -> 00:00:04,000\nSecond"
+	d := NewDecoder(strings.NewReader(input))
+
+	_, err := d.Decode()
+	assert.NoError(t, err, "Expected no error decoding first cue")
+
+	_, err = d.Decode()
+	assert.ErrorIs(t, err, ErrMissingArrow)
+	assert.EqualError(t, err, "expected ARROW, got TEXT at line 6, column 14")
+
+	var parseErr *ParseError
+	if assert.True(t, errors.As(err, &parseErr)) {
+		assert.Equal(t, 2, parseErr.Cue)
+		assert.Equal(t, 54, parseErr.Offset)
+		assert.Equal(t, 6, parseErr.Actual.Line)
+	}
+}
+
+func TestDecoder_DecodeErrorOffsetCRLF(t *testing.T) {
+	input := "1\r\n00:00:01,000 --> 00:00:02,000\r\nFirst\r\n\r\n2\r\n00:00:03,000 -> 00:00:04,000\r\nSecond"
+	d := NewDecoder(strings.NewReader(input))
+
+	_, err := d.Decode()
+	assert.NoError(t, err, "Expected no error decoding first cue")
+
+	_, err = d.Decode()
+	var parseErr *ParseError
+	if assert.True(t, errors.As(err, &parseErr)) {
+		assert.Equal(t, 59, parseErr.Offset)
+		assert.Equal(t, "->", input[parseErr.Offset:parseErr.Offset+2])
+
+		_, expected := Parse(strings.NewReader(input))
+		assert.Equal(t, expected, err, "Expected the same error as Parse")
+	}
+}
+
+func TestDecoder_MatchesParse(t *testing.T) {
+	input := "1\n00:00:01,000 --> 00:00:02,000\nFirst\n\n2\n00:00:03,000 --> 00:00:04,000\n- Second\n- Third\n\n3\n00:00:05,000 --> 00:00:06,000\n13,23 euros"
+
+	expected, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err, "Expected no error from Parse")
+
+	var cues []model.Cue
+	d := NewDecoder(strings.NewReader(input))
+	for {
+		cue, err := d.Decode()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err, "Expected no error from Decode")
+		cues = append(cues, cue)
+	}
+
+	assert.Equal(t, expected.Items, cues)
+}
@@ -0,0 +1,34 @@
+package srt
+
+import (
+	"io"
+
+	"github.com/florentsorel/srt/model"
+)
+
+// Encoder writes cues one at a time to an output stream, producing the same
+// output as model.Subtitles.Write for the same sequence of cues.
+type Encoder struct {
+	w     io.Writer
+	count int
+}
+
+// NewEncoder returns a new Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the given cue to the stream, separated from the previous one
+// by a blank line.
+func (e *Encoder) Encode(c model.Cue) error {
+	s := c.String()
+	if e.count > 0 {
+		s = "\n\n" + s
+	}
+
+	if _, err := io.WriteString(e.w, s); err != nil {
+		return err
+	}
+	e.count++
+	return nil
+}
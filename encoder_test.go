@@ -0,0 +1,31 @@
+package srt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/florentsorel/srt/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	subtitles := model.Subtitles{
+		Items: []model.Cue{
+			{Index: 1, Start: model.Duration(1 * time.Second), End: model.Duration(3 * time.Second), Text: "First"},
+			{Index: 2, Start: model.Duration(4 * time.Second), End: model.Duration(6 * time.Second), Text: "Second"},
+		},
+	}
+
+	var expected strings.Builder
+	_, err := subtitles.Write(&expected)
+	assert.NoError(t, err, "Expected no error from Write")
+
+	var sb strings.Builder
+	e := NewEncoder(&sb)
+	for _, cue := range subtitles.Items {
+		assert.NoError(t, e.Encode(cue), "Expected no error from Encode")
+	}
+
+	assert.Equal(t, expected.String(), sb.String())
+}
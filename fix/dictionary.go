@@ -0,0 +1,104 @@
+package fix
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidDictionary is returned by ReadDictionary for malformed lines.
+var ErrInvalidDictionary = errors.New("invalid dictionary entry")
+
+// Dictionary returns a rule replacing the words or phrases of replacements,
+// such as common OCR errors or house spellings. Entries only match whole
+// words, and the longest entries are tried first.
+func Dictionary(replacements map[string]string) Rule {
+	keys := make([]string, 0, len(replacements))
+	for k := range replacements {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) == 0 {
+		return rule{"dictionary", func(text string) string { return text }}
+	}
+
+	quoted := make([]string, len(keys))
+	for i, k := range keys {
+		quoted[i] = regexp.QuoteMeta(k)
+	}
+	pattern := regexp.MustCompile(strings.Join(quoted, "|"))
+
+	return rule{"dictionary", func(text string) string {
+		var b strings.Builder
+		last := 0
+		for from := 0; from < len(text); {
+			m := pattern.FindStringIndex(text[from:])
+			if m == nil {
+				break
+			}
+			start, end := from+m[0], from+m[1]
+			if !wordBoundary(text, start) || !wordBoundary(text, end) {
+				// Try again from the next character, as a shorter match
+				// may start within this one.
+				_, size := utf8.DecodeRuneInString(text[start:])
+				from = start + size
+				continue
+			}
+
+			b.WriteString(text[last:start])
+			b.WriteString(replacements[text[start:end]])
+			last, from = end, end
+		}
+		b.WriteString(text[last:])
+		return b.String()
+	}}
+}
+
+// wordBoundary reports whether the byte offset i of text is not within a
+// word.
+func wordBoundary(text string, i int) bool {
+	before, _ := utf8.DecodeLastRuneInString(text[:i])
+	after, _ := utf8.DecodeRuneInString(text[i:])
+	return i == 0 || i == len(text) || !isWordRune(before) || !isWordRune(after)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// ReadDictionary reads replacements written one per line as "wrong=right".
+// Blank lines and lines starting with "#" are ignored.
+func ReadDictionary(r io.Reader) (map[string]string, error) {
+	replacements := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		wrong, right, ok := strings.Cut(line, "=")
+		wrong, right = strings.TrimSpace(wrong), strings.TrimSpace(right)
+		if !ok || wrong == "" {
+			return nil, fmt.Errorf("line %d: %w", n, ErrInvalidDictionary)
+		}
+		replacements[wrong] = right
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return replacements, nil
+}
@@ -0,0 +1,54 @@
+package fix
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDictionary(t *testing.T) {
+	rule := Dictionary(map[string]string{
+		"teh":     "the",
+		"rn":      "m",
+		"wont":    "won't",
+		"wont do": "will not do",
+		"Mr":      "Mr.",
+	})
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"word", "teh end", "the end"},
+		{"whole words only", "tehran is not teh", "tehran is not the"},
+		{"longest first", "I wont do it, I wont.", "I will not do it, I won't."},
+		{"adjacent entries", "teh teh", "the the"},
+		{"shorter within longer", "arn rn", "arn m"},
+		{"accents", "été teh", "été the"},
+		{"none", "nothing here", "nothing here"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rule.Fix(tt.text))
+		})
+	}
+
+	assert.Equal(t, "teh", Dictionary(nil).Fix("teh"))
+}
+
+func TestReadDictionary(t *testing.T) {
+	replacements, err := ReadDictionary(strings.NewReader("# OCR errors\nteh = the\n\nrn=m\nempty=\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"teh": "the", "rn": "m", "empty": ""}, replacements)
+
+	_, err = ReadDictionary(strings.NewReader("teh=the\nbroken\n"))
+	assert.True(t, errors.Is(err, ErrInvalidDictionary))
+	assert.EqualError(t, err, "line 2: invalid dictionary entry")
+
+	_, err = ReadDictionary(strings.NewReader("=the\n"))
+	assert.True(t, errors.Is(err, ErrInvalidDictionary))
+}
@@ -0,0 +1,122 @@
+// Package fix corrects typography and the errors left by OCR in the text of
+// cues, with rule sets for several languages and user-supplied dictionaries.
+package fix
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/florentsorel/srt/model"
+)
+
+// ErrUnknownLanguage is returned by ForLanguage for languages without rules.
+var ErrUnknownLanguage = errors.New("unknown language")
+
+// Rule corrects text. Fix is given the text between formatting tags, never
+// the tags themselves.
+type Rule interface {
+	Name() string
+	Fix(text string) string
+}
+
+// Change is a cue whose text was changed by Apply.
+type Change struct {
+	// Cue is the 1-based position of the cue.
+	Cue    int
+	Before string
+	After  string
+}
+
+// Apply returns a new Subtitles with the rules applied in order to the text
+// of every cue, along with the cues that changed. Formatting tags are left
+// untouched.
+func Apply(s model.Subtitles, rules ...Rule) (model.Subtitles, []Change) {
+	fixed := s.MapText(func(text string) string {
+		for _, rule := range rules {
+			text = rule.Fix(text)
+		}
+		return text
+	})
+
+	var changes []Change
+	for i, cue := range fixed.Items {
+		if before := s.Items[i].Text; cue.Text != before {
+			changes = append(changes, Change{Cue: i + 1, Before: before, After: cue.Text})
+		}
+	}
+	return fixed, changes
+}
+
+// Diff writes changes to w, the lines of each cue before the fix prefixed
+// with "-" and after the fix with "+".
+func Diff(w io.Writer, changes []Change) error {
+	var b strings.Builder
+	for _, c := range changes {
+		fmt.Fprintf(&b, "cue %d\n", c.Cue)
+		for _, line := range strings.Split(c.Before, "\n") {
+			b.WriteString("-" + line + "\n")
+		}
+		for _, line := range strings.Split(c.After, "\n") {
+			b.WriteString("+" + line + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ForLanguage returns the rules for lang, given as an ISO 639-1 code with an
+// optional region such as "fr-CA". English, French and German are supported.
+func ForLanguage(lang string) ([]Rule, error) {
+	base := strings.ToLower(lang)
+	if i := strings.IndexAny(base, "-_"); i >= 0 {
+		base = base[:i]
+	}
+
+	switch base {
+	case "en":
+		return English(), nil
+	case "fr":
+		return French(), nil
+	case "de":
+		return German(), nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownLanguage, lang)
+}
+
+// English returns the rules for English text.
+func English() []Rule {
+	return []Rule{
+		DoubleApostrophes(),
+		CapitalI(),
+		Zero(),
+		SpaceBeforePunctuation(",.!?;:…"),
+		DoubleSpaces(),
+	}
+}
+
+// French returns the rules for French text, which puts a non-breaking space
+// before "?", "!", ":" and ";" and inside guillemets.
+func French() []Rule {
+	return []Rule{
+		DoubleApostrophes(),
+		Zero(),
+		SpaceBeforePunctuation(",.…"),
+		FrenchSpacing(),
+		DoubleSpaces(),
+	}
+}
+
+// German returns the rules for German text, which uses „low and high“
+// quotation marks.
+func German() []Rule {
+	return []Rule{
+		DoubleApostrophes(),
+		Zero(),
+		GermanQuotes(),
+		SpaceBeforePunctuation(",.!?;:…"),
+		DoubleSpaces(),
+	}
+}
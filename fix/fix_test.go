@@ -0,0 +1,80 @@
+package fix
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/florentsorel/srt/model"
+)
+
+func TestApply(t *testing.T) {
+	subtitles := model.Subtitles{Items: []model.Cue{
+		{Index: 1, Start: 0, End: model.Duration(time.Second), Text: "<i>l think</i>  so ."},
+		{Index: 2, Start: model.Duration(time.Second), End: model.Duration(2 * time.Second), Text: "Fine."},
+		{Index: 3, Start: model.Duration(2 * time.Second), End: model.Duration(3 * time.Second), Text: `{\an8}''N0''`},
+	}}
+
+	fixed, changes := Apply(subtitles, English()...)
+	assert.Equal(t, "<i>I think</i> so.", fixed.Items[0].Text)
+	assert.Equal(t, "Fine.", fixed.Items[1].Text)
+	assert.Equal(t, `{\an8}"NO"`, fixed.Items[2].Text)
+	assert.Equal(t, []Change{
+		{Cue: 1, Before: "<i>l think</i>  so .", After: "<i>I think</i> so."},
+		{Cue: 3, Before: `{\an8}''N0''`, After: `{\an8}"NO"`},
+	}, changes)
+
+	assert.Equal(t, "<i>l think</i>  so .", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+
+	_, changes = Apply(subtitles)
+	assert.Empty(t, changes)
+}
+
+func TestDiff(t *testing.T) {
+	var b bytes.Buffer
+	err := Diff(&b, []Change{
+		{Cue: 1, Before: "l think\nso .", After: "I think\nso."},
+		{Cue: 4, Before: "N0", After: "NO"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "cue 1\n-l think\n-so .\n+I think\n+so.\ncue 4\n-N0\n+NO\n", b.String())
+}
+
+func TestForLanguage(t *testing.T) {
+	tests := []struct {
+		lang     string
+		expected []Rule
+	}{
+		{"en", English()},
+		{"EN-us", English()},
+		{"fr_CA", French()},
+		{"de", German()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			rules, err := ForLanguage(tt.lang)
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.expected), len(rules))
+			for i := range rules {
+				assert.Equal(t, tt.expected[i].Name(), rules[i].Name())
+			}
+		})
+	}
+
+	_, err := ForLanguage("xx")
+	assert.True(t, errors.Is(err, ErrUnknownLanguage))
+	assert.EqualError(t, err, `unknown language "xx"`)
+}
+
+func TestFrench(t *testing.T) {
+	subtitles := model.Subtitles{Items: []model.Cue{
+		{Index: 1, Start: 0, End: model.Duration(time.Second), Text: "Quoi  ? C'est  fini , non?"},
+	}}
+
+	fixed, _ := Apply(subtitles, French()...)
+	assert.Equal(t, "Quoi\u00a0? C'est fini, non\u00a0?", fixed.Items[0].Text)
+}
@@ -0,0 +1,145 @@
+package fix
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// rule is a Rule applying a function to the text.
+type rule struct {
+	name string
+	fix  func(text string) string
+}
+
+func (r rule) Name() string { return r.name }
+
+func (r rule) Fix(text string) string { return r.fix(text) }
+
+var doubleSpaces = regexp.MustCompile(`[ \t]{2,}`)
+
+// DoubleSpaces replaces runs of spaces and tabs with a single space.
+func DoubleSpaces() Rule {
+	return rule{"double-spaces", func(text string) string {
+		return doubleSpaces.ReplaceAllString(text, " ")
+	}}
+}
+
+// SpaceBeforePunctuation removes the spaces before the punctuation marks in
+// marks. Spaces after a dialogue dash are kept, as in "- ...and then".
+func SpaceBeforePunctuation(marks string) Rule {
+	class := regexp.QuoteMeta(marks)
+	pattern := regexp.MustCompile(`([^\s\-–])[ \t]+([` + class + `])`)
+	return rule{"space-before-punctuation", func(text string) string {
+		return pattern.ReplaceAllString(text, "$1$2")
+	}}
+}
+
+// DoubleApostrophes replaces two apostrophes, often read by OCR instead of
+// a double quote, with a double quote.
+func DoubleApostrophes() Rule {
+	return rule{"double-apostrophes", func(text string) string {
+		return strings.ReplaceAll(text, "''", `"`)
+	}}
+}
+
+var (
+	// lowercaseI matches a lowercase l read by OCR instead of the pronoun I,
+	// along with the characters around it.
+	lowercaseI = regexp.MustCompile(`(^|[^\p{L}\p{N}'’])l(['’](?:m|ll|d|ve)\b|[\s,.!?]|$)`)
+	// lowercaseIWord matches words starting with a lowercase l read by OCR
+	// instead of a capital I, along with the characters around them.
+	lowercaseIWord = regexp.MustCompile(`(^|[^\p{L}\p{N}'’])l(t|f|n|s|sn['’]t)([^\p{L}\p{N}]|$)`)
+)
+
+// CapitalI replaces a lowercase l read by OCR instead of a capital I in
+// English text, as in "l'm", "l think" or "lt's".
+func CapitalI() Rule {
+	return rule{"capital-i", func(text string) string {
+		// Matches may share the character between them, as in "l l", so
+		// replace until nothing changes.
+		for {
+			fixed := lowercaseI.ReplaceAllString(text, "${1}I${2}")
+			fixed = lowercaseIWord.ReplaceAllString(fixed, "${1}I${2}${3}")
+			if fixed == text {
+				return text
+			}
+			text = fixed
+		}
+	}}
+}
+
+var word = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// Zero replaces the digit 0 read by OCR instead of the letter O in words
+// without other digits, such as "N0" or "g00d". The letter is a capital if
+// the other letters of the word are.
+func Zero() Rule {
+	return rule{"zero", func(text string) string {
+		return word.ReplaceAllStringFunc(text, func(w string) string {
+			letters, upper := 0, true
+			for _, r := range w {
+				switch {
+				case unicode.IsLetter(r):
+					letters++
+					upper = upper && !unicode.IsLower(r)
+				case r != '0':
+					return w
+				}
+			}
+			if letters == 0 || !strings.ContainsRune(w, '0') {
+				return w
+			}
+			if upper {
+				return strings.ReplaceAll(w, "0", "O")
+			}
+			return strings.ReplaceAll(w, "0", "o")
+		})
+	}}
+}
+
+var (
+	// frenchMarks matches the punctuation marks preceded by a non-breaking
+	// space in French, along with the character before them.
+	frenchMarks      = regexp.MustCompile(`([^\s\x{a0}\x{202f}?!:;])[ \t\x{a0}\x{202f}]*([?!:;]+)`)
+	openingGuillemet = regexp.MustCompile(`«[ \t\x{a0}\x{202f}]*`)
+	closingGuillemet = regexp.MustCompile(`[ \t\x{a0}\x{202f}]*»`)
+	germanQuoted     = regexp.MustCompile(`"([^"]*)"`)
+)
+
+const (
+	nonBreakingSpace = "\u00a0"
+	germanOpening    = "\u201e"
+	germanClosing    = "\u201c"
+)
+
+// FrenchSpacing puts a non-breaking space before "?", "!", ":" and ";", and
+// inside guillemets. Colons in times and addresses, such as "10:30" or
+// "http://", are left alone.
+func FrenchSpacing() Rule {
+	return rule{"french-spacing", func(text string) string {
+		var b strings.Builder
+		last := 0
+		for _, m := range frenchMarks.FindAllStringSubmatchIndex(text, -1) {
+			if m[1] < len(text) && text[m[4]:m[5]] == ":" && strings.ContainsAny(text[m[1]:m[1]+1], "0123456789/") {
+				continue
+			}
+			b.WriteString(text[last:m[3]])
+			b.WriteString(nonBreakingSpace)
+			b.WriteString(text[m[4]:m[5]])
+			last = m[1]
+		}
+		b.WriteString(text[last:])
+
+		fixed := openingGuillemet.ReplaceAllString(b.String(), "«"+nonBreakingSpace)
+		return closingGuillemet.ReplaceAllString(fixed, nonBreakingSpace+"»")
+	}}
+}
+
+// GermanQuotes replaces pairs of straight double quotes with German
+// quotation marks.
+func GermanQuotes() Rule {
+	return rule{"german-quotes", func(text string) string {
+		return germanQuoted.ReplaceAllString(text, germanOpening+"${1}"+germanClosing)
+	}}
+}
@@ -0,0 +1,55 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     Rule
+		text     string
+		expected string
+	}{
+		{"double spaces", DoubleSpaces(), "Hello  there,\t\tyou.", "Hello there, you."},
+		{"space before punctuation", SpaceBeforePunctuation(",.!?;:…"), "Hello , you ! Really ?", "Hello, you! Really?"},
+		{"space after dash kept", SpaceBeforePunctuation(",.!?;:…"), "- ...and then", "- ...and then"},
+		{"double apostrophes", DoubleApostrophes(), "He said ''no''.", `He said "no".`},
+		{"capital i", CapitalI(), "l think l'm right, and l'll go.", "I think I'm right, and I'll go."},
+		{"capital i words", CapitalI(), "lt's here. ln fact, lsn't it?", "It's here. In fact, Isn't it?"},
+		{"capital i at end", CapitalI(), "You and l", "You and I"},
+		{"lowercase l kept", CapitalI(), "Hello, lovely l-shaped", "Hello, lovely l-shaped"},
+		{"lowercase l after accented letter kept", CapitalI(), "Back from Köln, ls it?", "Back from Köln, Is it?"},
+		{"zero", Zero(), "N0, it's g00d.", "NO, it's good."},
+		{"zero in numbers kept", Zero(), "10 times, 2000, A10", "10 times, 2000, A10"},
+		{"french spacing", FrenchSpacing(), "Quoi ? Non! Voilà : «Bonjour»", "Quoi\u00a0? Non\u00a0! Voilà\u00a0: «\u00a0Bonjour\u00a0»"},
+		{"french spacing repeated marks", FrenchSpacing(), "Hein ?!", "Hein\u00a0?!"},
+		{"french spacing times kept", FrenchSpacing(), "À 10:30, sur http://example.com", "À 10:30, sur http://example.com"},
+		{"german quotes", GermanQuotes(), `Er sagte "Nein" und "Ja".`, "Er sagte „Nein“ und „Ja“."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rule.Fix(tt.text))
+		})
+	}
+}
+
+func TestRules_Names(t *testing.T) {
+	var names []string
+	for _, rule := range append(English(), FrenchSpacing(), GermanQuotes(), Dictionary(nil)) {
+		names = append(names, rule.Name())
+	}
+	assert.Equal(t, []string{
+		"double-apostrophes",
+		"capital-i",
+		"zero",
+		"space-before-punctuation",
+		"double-spaces",
+		"french-spacing",
+		"german-quotes",
+		"dictionary",
+	}, names)
+}
@@ -2,6 +2,7 @@ package lexer
 
 import (
 	"errors"
+	"sort"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -9,6 +10,25 @@ import (
 	"github.com/florentsorel/srt/internal/token"
 )
 
+// ErrInvalidUTF8 is returned by New when the input is not valid UTF-8.
+var ErrInvalidUTF8 = errors.New("input string is not valid UTF-8")
+
+// InvalidUTF8Error reports the position of the first invalid UTF-8 sequence
+// in the input. It wraps ErrInvalidUTF8.
+type InvalidUTF8Error struct {
+	Line   int
+	Column int
+	Offset int
+}
+
+func (e *InvalidUTF8Error) Error() string {
+	return ErrInvalidUTF8.Error()
+}
+
+func (e *InvalidUTF8Error) Unwrap() error {
+	return ErrInvalidUTF8
+}
+
 type Lexer struct {
 	input           string
 	length          int
@@ -18,17 +38,37 @@ type Lexer struct {
 	ch              rune
 	line            int
 	column          int
+	// shifts maps offsets in input back to the original input.
+	shifts []shift
+}
+
+// shift records that from offset at of the normalized input onwards, the
+// original input is delta bytes further.
+type shift struct {
+	at, delta int
 }
 
 // New creates and initializes a new lexer for the given input string.
+// A leading UTF-8 byte order mark is dropped and CRLF line endings are
+// normalized to LF.
 // It reads the first character immediately to set up the lexer's state.
 // The returned *lexer is intended for internal use only.
 func New(input string) (*Lexer, error) {
-	if !utf8.ValidString(input) {
-		return nil, errors.New("input string is not valid UTF-8")
+	normalized, shifts, invalid := normalize(input)
+	if len(invalid) > 0 {
+		return nil, invalid[0]
 	}
+	return newLexer(normalized, shifts), nil
+}
 
-	input = strings.ReplaceAll(input, "\r\n", "\n")
+// NewRepaired is like New, but replaces each invalid UTF-8 byte with
+// U+FFFD instead of failing, and reports where they were.
+func NewRepaired(input string) (*Lexer, []*InvalidUTF8Error) {
+	normalized, shifts, invalid := normalize(input)
+	return newLexer(normalized, shifts), invalid
+}
+
+func newLexer(input string, shifts []shift) *Lexer {
 	l := &Lexer{
 		input:           input,
 		length:          len(input),
@@ -38,9 +78,60 @@ func New(input string) (*Lexer, error) {
 		ch:              0,
 		line:            1,
 		column:          0,
+		shifts:          shifts,
 	}
 	l.readChar()
-	return l, nil
+	return l
+}
+
+// normalize drops the byte order mark of input, converts CRLF line endings
+// to LF and replaces invalid UTF-8 bytes with U+FFFD. It returns the shifts
+// between the normalized and the original input, and the position of each
+// invalid byte in the original input.
+func normalize(input string) (string, []shift, []*InvalidUTF8Error) {
+	var b strings.Builder
+	b.Grow(len(input))
+	var shifts []shift
+	var invalid []*InvalidUTF8Error
+
+	i := 0
+	if strings.HasPrefix(input, "\ufeff") {
+		i = len("\ufeff")
+		shifts = append(shifts, shift{0, i})
+	}
+	line, column := 1, 0
+	for i < len(input) {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		switch {
+		case r == utf8.RuneError && size <= 1:
+			column++
+			invalid = append(invalid, &InvalidUTF8Error{Line: line, Column: column, Offset: i})
+			b.WriteRune(utf8.RuneError)
+			shifts = append(shifts, shift{b.Len(), i + 1 - b.Len()})
+		case r == '\r' && strings.HasPrefix(input[i+size:], "\n"):
+			shifts = append(shifts, shift{b.Len(), i + 1 - b.Len()})
+		default:
+			b.WriteString(input[i : i+size])
+			if r == '\n' {
+				line++
+				column = 0
+			} else if r != '\r' {
+				column++
+			}
+		}
+		i += size
+	}
+	return b.String(), shifts, invalid
+}
+
+// OriginalOffset returns the byte offset in the input given to New of the
+// given offset in the normalized input, which token offsets refer to.
+func (l *Lexer) OriginalOffset(offset int) int {
+	i := sort.Search(len(l.shifts), func(i int) bool { return l.shifts[i].at > offset })
+	if i == 0 {
+		return offset
+	}
+	return offset + l.shifts[i-1].delta
 }
 
 // NextToken lexes the next token from the input and returns it.
@@ -50,7 +141,7 @@ func (l *Lexer) NextToken() token.Token {
 	l.skipWhitespace()
 
 	if l.ch == 0 {
-		return token.NewToken(token.EOF, "", l.line, l.column)
+		return token.NewToken(token.EOF, "", l.line, l.column, l.currentPosition)
 	}
 
 	switch {
@@ -67,9 +158,9 @@ func (l *Lexer) NextToken() token.Token {
 			literal = l.input[start:l.currentPosition]
 
 			if isTimestampLiteral(literal) {
-				tok = token.NewToken(token.TIMESTAMP, literal, line, column)
+				tok = token.NewToken(token.TIMESTAMP, literal, line, column, start)
 			} else {
-				tok = token.NewToken(token.TEXT, literal, line, column)
+				tok = token.NewToken(token.TEXT, literal, line, column, start)
 			}
 		} else {
 			if l.ch != '\n' && l.ch != 0 {
@@ -77,12 +168,13 @@ func (l *Lexer) NextToken() token.Token {
 					l.readChar()
 				}
 				literal = l.input[start:l.currentPosition]
-				tok = token.NewToken(token.TEXT, literal, line, column)
+				tok = token.NewToken(token.TEXT, literal, line, column, start)
 			} else {
-				tok = token.NewToken(token.INDEX, literal, line, column)
+				tok = token.NewToken(token.INDEX, literal, line, column, start)
 			}
 		}
 	case l.ch == '\n':
+		start := l.currentPosition
 		line := l.line
 		column := l.column
 
@@ -90,28 +182,30 @@ func (l *Lexer) NextToken() token.Token {
 
 		if l.ch == '\n' {
 			l.readChar()
-			return token.NewToken(token.EOC, "\n\n", line, column)
+			return token.NewToken(token.EOC, "\n\n", line, column, start)
 		}
 
-		return token.NewToken(token.LF, "\n", line, column)
+		return token.NewToken(token.LF, "\n", line, column, start)
 	case l.ch == '-' && l.peekChar(1) == '-' && l.peekChar(2) == '>':
 		start := l.currentPosition
 		column := l.column
 
-		if start == 0 || l.input[start-1] != ' ' {
-			return token.NewToken(token.ILLEGAL, "-->", l.line, column)
-		}
-
+		// Consume the arrow even when it is illegal, so that a caller
+		// skipping bad tokens always makes progress.
 		l.readChar()
 		l.readChar()
 		l.readChar()
 
+		if start == 0 || l.input[start-1] != ' ' {
+			return token.NewToken(token.ILLEGAL, "-->", l.line, column, start)
+		}
+
 		if l.ch != ' ' {
-			return token.NewToken(token.ILLEGAL, "-->", l.line, column)
+			return token.NewToken(token.ILLEGAL, "-->", l.line, column, start)
 		}
 
 		literal := l.input[start:l.currentPosition]
-		return token.NewToken(token.ARROW, literal, l.line, column)
+		return token.NewToken(token.ARROW, literal, l.line, column, start)
 	default:
 		start := l.currentPosition
 		line := l.line
@@ -120,12 +214,34 @@ func (l *Lexer) NextToken() token.Token {
 		for l.ch != 0 && l.ch != '\n' {
 			l.readChar()
 		}
-		return token.NewToken(token.TEXT, l.input[start:l.currentPosition], line, column)
+		return token.NewToken(token.TEXT, l.input[start:l.currentPosition], line, column, start)
 	}
 
 	return tok
 }
 
+// Line returns the content of the given 1-based line of the input, without
+// its line terminator, or an empty string if the line does not exist.
+func (l *Lexer) Line(n int) string {
+	if n < 1 {
+		return ""
+	}
+
+	rest := l.input
+	for i := 1; i < n; i++ {
+		idx := strings.IndexByte(rest, '\n')
+		if idx < 0 {
+			return ""
+		}
+		rest = rest[idx+1:]
+	}
+
+	if idx := strings.IndexByte(rest, '\n'); idx >= 0 {
+		rest = rest[:idx]
+	}
+	return rest
+}
+
 // readChar advances the Lexer by one rune in the input, updating its
 // currentPosition, readPosition, runePosition, line, and column.
 // Handles multibyte UTF-8 characters.
@@ -42,11 +42,70 @@ func TestNewLexerWithEmptyInput(t *testing.T) {
 	assert.Equal(t, 0, lexer.column, "Expected initial column to be 0, got %d", lexer.column)
 }
 
+func TestNewLexerWithBOM(t *testing.T) {
+	lexer, err := New("\ufeff1\n")
+	assert.NoError(t, err, "Expected no error from New")
+
+	tok := lexer.NextToken()
+	assert.Equal(t, token.INDEX, tok.Kind, "Expected first token to be INDEX, got %q", tok.Kind)
+	assert.Equal(t, "1", tok.Literal, "Expected first token literal to be \"1\", got %q", tok.Literal)
+}
+
 func TestNewLexerWithInvalidUTF8String(t *testing.T) {
 	input := []byte{0x48, 0x65, 0x6C, 0x6C, 0x6F, 0x20, 0xC3, 0x28} // "Hello " + broken sequence
 	lexer, err := New(string(input))
 	assert.Error(t, err, "input string is not valid UTF-8")
 	assert.Nil(t, lexer, "Expected lexer to be nil on error, got initialized lexer")
+
+	var utf8Err *InvalidUTF8Error
+	if assert.ErrorAs(t, err, &utf8Err) {
+		assert.ErrorIs(t, err, ErrInvalidUTF8)
+		assert.Equal(t, 1, utf8Err.Line)
+		assert.Equal(t, 7, utf8Err.Column)
+		assert.Equal(t, 6, utf8Err.Offset)
+	}
+}
+
+func TestNewRepaired(t *testing.T) {
+	lexer, invalid := NewRepaired("1\r\nHello \xc3(\r\nBye \xff")
+
+	if assert.Len(t, invalid, 2) {
+		assert.Equal(t, InvalidUTF8Error{Line: 2, Column: 7, Offset: 9}, *invalid[0])
+		assert.Equal(t, InvalidUTF8Error{Line: 3, Column: 5, Offset: 17}, *invalid[1])
+	}
+	assert.Equal(t, "1\nHello \ufffd(\nBye \ufffd", lexer.input)
+}
+
+func TestOriginalOffset(t *testing.T) {
+	lexer, _ := NewRepaired("\ufeff1\r\n\xffA\r\nB")
+	assert.Equal(t, "1\n\ufffdA\nB", lexer.input)
+
+	tests := []struct {
+		offset   int
+		original int
+	}{
+		{0, 3},  // "1"
+		{1, 5},  // "\n"
+		{2, 6},  // "\ufffd"
+		{5, 7},  // "A"
+		{6, 9},  // "\n"
+		{7, 10}, // "B"
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.original, lexer.OriginalOffset(tt.offset), "Expected offset %d to map to %d", tt.offset, tt.original)
+	}
+}
+
+func TestLine(t *testing.T) {
+	lexer, err := New("first\r\nsecond\n\nfourth")
+	assert.NoError(t, err, "Expected no error from New")
+
+	assert.Equal(t, "first", lexer.Line(1))
+	assert.Equal(t, "second", lexer.Line(2))
+	assert.Equal(t, "", lexer.Line(3))
+	assert.Equal(t, "fourth", lexer.Line(4))
+	assert.Equal(t, "", lexer.Line(5))
+	assert.Equal(t, "", lexer.Line(0))
 }
 
 // TestReadChar verifies the lexer correctly reads characters,
@@ -102,25 +161,26 @@ func TestNextToken(t *testing.T) {
 		literal string
 		line    int
 		column  int
+		offset  int
 	}{
-		{token.INDEX, "11", 1, 1},
-		{token.LF, "\n", 2, 0},
-		{token.TIMESTAMP, "00:00:01,000", 2, 1},
-		{token.ARROW, "-->", 2, 14},
-		{token.TIMESTAMP, "00:00:04,000", 2, 18},
-		{token.LF, "\n", 3, 0},
-		{token.TEXT, "Hello World!", 3, 1},
-		{token.LF, "\n", 4, 0},
-		{token.TEXT, "Ça va ? 😀", 4, 1},
-		{token.EOC, "\n\n", 5, 0},
-		{token.INDEX, "2", 6, 1},
-		{token.LF, "\n", 7, 0},
-		{token.TIMESTAMP, "00:00:05,000", 7, 1},
-		{token.ARROW, "-->", 7, 14},
-		{token.TIMESTAMP, "00:00:07,000", 7, 18},
-		{token.LF, "\n", 8, 0},
-		{token.TEXT, "This is a test.", 8, 1},
-		{token.EOF, "", 8, 15},
+		{token.INDEX, "11", 1, 1, 0},
+		{token.LF, "\n", 2, 0, 2},
+		{token.TIMESTAMP, "00:00:01,000", 2, 1, 3},
+		{token.ARROW, "-->", 2, 14, 16},
+		{token.TIMESTAMP, "00:00:04,000", 2, 18, 20},
+		{token.LF, "\n", 3, 0, 32},
+		{token.TEXT, "Hello World!", 3, 1, 33},
+		{token.LF, "\n", 4, 0, 45},
+		{token.TEXT, "Ça va ? 😀", 4, 1, 46},
+		{token.EOC, "\n\n", 5, 0, 59},
+		{token.INDEX, "2", 6, 1, 61},
+		{token.LF, "\n", 7, 0, 62},
+		{token.TIMESTAMP, "00:00:05,000", 7, 1, 63},
+		{token.ARROW, "-->", 7, 14, 76},
+		{token.TIMESTAMP, "00:00:07,000", 7, 18, 80},
+		{token.LF, "\n", 8, 0, 92},
+		{token.TEXT, "This is a test.", 8, 1, 93},
+		{token.EOF, "", 8, 15, 108},
 	}
 
 	for i, expected := range tests {
@@ -129,5 +189,6 @@ func TestNextToken(t *testing.T) {
 		assert.Equal(t, expected.literal, tok.Literal, "[%d] Expected token literal %q, got %q", i, expected.literal, tok.Literal)
 		assert.Equal(t, expected.line, tok.Line, "[%d] Expected token line %d, got %d", i, expected.line, tok.Line)
 		assert.Equal(t, expected.column, tok.Column, "[%d] Expected token column %d, got %d", i, expected.column, tok.Column)
+		assert.Equal(t, expected.offset, tok.Offset, "[%d] Expected token offset %d, got %d", i, expected.offset, tok.Offset)
 	}
 }
@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/florentsorel/srt/internal/lexer"
+	"github.com/florentsorel/srt/internal/token"
+)
+
+// Sentinel causes wrapped by Error. Use errors.Is to test for them.
+var (
+	ErrUnexpectedToken = errors.New("unexpected token")
+	ErrBadIndex        = errors.New("bad cue index")
+	ErrBadTimestamp    = errors.New("bad timestamp")
+	ErrMissingArrow    = errors.New("missing arrow")
+	ErrEmptyText       = errors.New("empty text")
+	ErrInvalidUTF8     = lexer.ErrInvalidUTF8
+)
+
+// Error is a parse error with the position of the offending token.
+type Error struct {
+	// Line and Column are 1-based; Column counts runes.
+	Line   int
+	Column int
+	// Offset is the byte offset of the offending token in the original
+	// input, byte order mark and CRLF line endings included.
+	Offset int
+	// Expected lists the token kinds that would have been accepted.
+	Expected []token.TokenKind
+	// Actual is the token that was found.
+	Actual token.Token
+	// Cue is the 1-based ordinal of the cue being parsed, or 0 if the error
+	// is not tied to a cue.
+	Cue int
+	// Source is the content of the offending line.
+	Source string
+	// Err is the sentinel cause of the error.
+	Err error
+}
+
+func (e *Error) Error() string {
+	if len(e.Expected) > 0 {
+		kinds := make([]string, len(e.Expected))
+		for i, k := range e.Expected {
+			kinds[i] = string(k)
+		}
+		return fmt.Sprintf("expected %s, got %s at line %d, column %d", strings.Join(kinds, " or "), e.Actual.Kind, e.Line, e.Column)
+	}
+
+	if e.Actual.Literal != "" {
+		return fmt.Sprintf("%s %q at line %d, column %d", e.Err, e.Actual.Literal, e.Line, e.Column)
+	}
+	return fmt.Sprintf("%s at line %d, column %d", e.Err, e.Line, e.Column)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Excerpt returns the offending source line followed by a line with a caret
+// pointing at the error column.
+func (e *Error) Excerpt() string {
+	column := e.Column
+	if column < 1 {
+		column = 1
+	}
+	return e.Source + "\n" + strings.Repeat(" ", column-1) + "^"
+}
+
+// NewFromString creates a lexer for the given input and a parser on top of
+// it. Lexer failures are returned as *Error.
+func NewFromString(input string) (*Parser, error) {
+	l, err := lexer.New(input)
+	if err != nil {
+		var utf8Err *lexer.InvalidUTF8Error
+		if errors.As(err, &utf8Err) {
+			return nil, &Error{
+				Line:   utf8Err.Line,
+				Column: utf8Err.Column,
+				Offset: utf8Err.Offset,
+				Source: sourceLine(input, utf8Err.Line),
+				Err:    ErrInvalidUTF8,
+			}
+		}
+		return nil, err
+	}
+	return New(l), nil
+}
+
+// NewFromStringRepaired is like NewFromString, but replaces invalid UTF-8
+// bytes with U+FFFD instead of failing. Each line holding invalid bytes is
+// reported as a Diagnostic whose Err is an *Error wrapping ErrInvalidUTF8.
+func NewFromStringRepaired(input string) (*Parser, []Diagnostic) {
+	l, invalid := lexer.NewRepaired(input)
+
+	var diagnostics []Diagnostic
+	for _, e := range invalid {
+		if n := len(diagnostics); n > 0 && diagnostics[n-1].StartLine == e.Line {
+			continue
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Err: &Error{
+				Line:   e.Line,
+				Column: e.Column,
+				Offset: e.Offset,
+				Source: sourceLine(input, e.Line),
+				Err:    ErrInvalidUTF8,
+			},
+			StartLine: e.Line,
+			EndLine:   e.Line,
+		})
+	}
+	return New(l), diagnostics
+}
+
+// sourceLine returns the given 1-based line of input with invalid UTF-8
+// sequences replaced, so that it can be safely displayed.
+func sourceLine(input string, n int) string {
+	lines := strings.SplitN(input, "\n", n+1)
+	if n < 1 || n > len(lines) {
+		return ""
+	}
+	return strings.ToValidUTF8(strings.TrimSuffix(lines[n-1], "\r"), "�")
+}
@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/florentsorel/srt/internal/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCauses(t *testing.T) {
+	tests := []struct {
+		input string
+		cause error
+	}{
+		{"Hello", ErrUnexpectedToken},
+		{"99999999999999999999\n00:00:00,000 --> 00:00:01,000\nText", ErrBadIndex},
+		{"1\n00:00:00,45 --> 00:00:01,000\nText", ErrBadTimestamp},
+		{"1\n00:00:61,000 --> 00:00:01,000\nText", ErrBadTimestamp},
+		{"1\n00:00:00,000 -> 00:00:01,000\nText", ErrMissingArrow},
+		{"1\n00:00:00,000 --> 00:00:01,000\n", ErrEmptyText},
+	}
+
+	for i, tt := range tests {
+		p, err := NewFromString(tt.input)
+		assert.NoError(t, err, "[%d] expected no error from NewFromString", i)
+
+		_, err = p.Parse()
+		assert.ErrorIs(t, err, tt.cause, "[%d] expected cause %v, got %v", i, tt.cause, err)
+
+		var parseErr *Error
+		assert.True(t, errors.As(err, &parseErr), "[%d] expected *Error, got %T", i, err)
+	}
+}
+
+func TestErrorFields(t *testing.T) {
+	input := "1\n00:00:00,000 --> 00:00:01,000\nFirst\n\n2\n00:00:02,000 test\nSecond"
+	p, _ := NewFromString(input)
+	_, err := p.Parse()
+
+	var parseErr *Error
+	if !assert.ErrorAs(t, err, &parseErr) {
+		return
+	}
+
+	assert.Equal(t, 6, parseErr.Line)
+	assert.Equal(t, 14, parseErr.Column)
+	assert.Equal(t, 54, parseErr.Offset)
+	assert.Equal(t, []token.TokenKind{token.ARROW}, parseErr.Expected)
+	assert.Equal(t, token.NewToken(token.TEXT, "test", 6, 14, 54), parseErr.Actual)
+	assert.Equal(t, 2, parseErr.Cue)
+	assert.Equal(t, "00:00:02,000 test", parseErr.Source)
+	assert.Equal(t, "00:00:02,000 test\n             ^", parseErr.Excerpt())
+	assert.EqualError(t, err, "expected ARROW, got TEXT at line 6, column 14")
+}
+
+func TestErrorOffsetCRLF(t *testing.T) {
+	input := "\ufeff1\r\n00:00:00,000 --> 00:00:01,000\r\nFirst\r\n\r\n2\r\n00:00:02,000 test\r\nSecond"
+	p, _ := NewFromString(input)
+	_, err := p.Parse()
+
+	var parseErr *Error
+	if assert.ErrorAs(t, err, &parseErr) {
+		assert.Equal(t, 62, parseErr.Offset)
+		assert.Equal(t, 62, parseErr.Actual.Offset)
+		assert.Equal(t, "test", input[parseErr.Offset:parseErr.Offset+4])
+		assert.Equal(t, 6, parseErr.Line)
+		assert.Equal(t, 14, parseErr.Column)
+	}
+}
+
+func TestErrorInvalidTimestampMessage(t *testing.T) {
+	p, _ := NewFromString("1\n00:61:00,000 --> 01:00:00,000\nText")
+	_, err := p.Parse()
+	assert.EqualError(t, err, `bad timestamp "00:61:00,000" at line 2, column 1`)
+}
+
+func TestNewFromStringInvalidUTF8(t *testing.T) {
+	input := "1\n00:00:00,000 --> 00:00:01,000\nCaf\xe9"
+	p, err := NewFromString(input)
+	assert.Nil(t, p)
+	assert.ErrorIs(t, err, ErrInvalidUTF8)
+
+	var parseErr *Error
+	if assert.ErrorAs(t, err, &parseErr) {
+		assert.Equal(t, 3, parseErr.Line)
+		assert.Equal(t, 4, parseErr.Column)
+		assert.Equal(t, 35, parseErr.Offset)
+		assert.Equal(t, "Caf�", parseErr.Source)
+		assert.EqualError(t, err, "input string is not valid UTF-8 at line 3, column 4")
+	}
+}
@@ -11,10 +11,22 @@ import (
 	"github.com/florentsorel/srt/model"
 )
 
+// Diagnostic describes a region of the input that was skipped while
+// recovering from a malformed cue in lenient mode, or a line whose invalid
+// UTF-8 bytes were replaced.
+type Diagnostic struct {
+	// Err is the error that caused the region to be skipped or repaired.
+	Err error
+	// StartLine and EndLine delimit the affected lines (inclusive).
+	StartLine int
+	EndLine   int
+}
+
 type Parser struct {
 	lexer        *lexer.Lexer
 	currentToken token.Token
 	nextToken    token.Token
+	cue          int
 }
 
 // New creates a new parser with the given lexer and initializes its state.
@@ -36,6 +48,7 @@ func (p *Parser) Parse() ([]model.Cue, error) {
 	var cues []model.Cue
 
 	for p.currentToken.Kind != token.EOF {
+		p.cue++
 		cue, err := p.parseCue()
 		if err != nil {
 			return nil, err
@@ -46,66 +59,136 @@ func (p *Parser) Parse() ([]model.Cue, error) {
 	return cues, nil
 }
 
+// ParseLenient parses the entire input, skipping malformed cues instead of
+// aborting. After an error, the parser resyncs to the next line that looks
+// like the start of a cue (an INDEX or a TIMESTAMP line). Every skipped
+// region is reported as a Diagnostic.
+func (p *Parser) ParseLenient() ([]model.Cue, []Diagnostic) {
+	var cues []model.Cue
+	var diagnostics []Diagnostic
+
+	for p.currentToken.Kind != token.EOF {
+		start := p.currentToken
+		p.cue++
+
+		var cue *model.Cue
+		var err error
+		if p.currentToken.Kind == token.TIMESTAMP {
+			cue, err = p.parseCueBody(model.Cue{Index: len(cues) + 1})
+		} else {
+			cue, err = p.parseCue()
+		}
+
+		if err == nil {
+			cues = append(cues, *cue)
+			continue
+		}
+
+		// Always move past the token the failed cue started at, unless the
+		// offending token is itself the start of the next cue.
+		if p.currentToken == start || !p.atCueStart() {
+			p.readToken()
+		}
+
+		endLine := p.currentToken.Line
+		for p.currentToken.Kind != token.EOF && !p.atCueStart() {
+			endLine = p.currentToken.Line
+			p.readToken()
+		}
+		if p.currentToken.Kind != token.EOF {
+			endLine = p.currentToken.Line - 1
+		}
+		if endLine < start.Line {
+			endLine = start.Line
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Err:       err,
+			StartLine: start.Line,
+			EndLine:   endLine,
+		})
+	}
+
+	return cues, diagnostics
+}
+
+// atCueStart reports whether the current token looks like the beginning of a
+// cue: an index followed by a line feed, or a timestamp followed by an arrow.
+func (p *Parser) atCueStart() bool {
+	switch p.currentToken.Kind {
+	case token.INDEX:
+		return p.nextToken.Kind == token.LF
+	case token.TIMESTAMP:
+		return p.nextToken.Kind == token.ARROW
+	}
+	return false
+}
+
 // parseCue parses a single cue from the token stream.
 func (p *Parser) parseCue() (*model.Cue, error) {
 	var c model.Cue
 
 	// Cue index
 	if p.currentToken.Kind != token.INDEX {
-		return nil, fmt.Errorf("expected INDEX, got %s at line %d, column %d", p.currentToken.Kind, p.currentToken.Line, p.currentToken.Column)
+		return nil, p.unexpected(ErrUnexpectedToken, token.INDEX)
 	}
 	index, err := strconv.Atoi(p.currentToken.Literal)
 	if err != nil {
-		return nil, err
+		return nil, p.unexpected(ErrBadIndex)
 	}
 	c.Index = index
 	p.readToken()
 
 	// Line feed
 	if p.currentToken.Kind != token.LF {
-		return nil, fmt.Errorf("expected LF, got %s at line %d, column %d", p.currentToken.Kind, p.currentToken.Line, p.currentToken.Column)
+		return nil, p.unexpected(ErrUnexpectedToken, token.LF)
 	}
 	p.readToken()
 
+	return p.parseCueBody(c)
+}
+
+// parseCueBody parses the timing line and the text of a cue, starting at the
+// start timestamp. The given cue carries the fields parsed so far.
+func (p *Parser) parseCueBody(c model.Cue) (*model.Cue, error) {
 	// Start
 	if p.currentToken.Kind != token.TIMESTAMP {
-		return nil, fmt.Errorf("expected TIMESTAMP, got %s at line %d, column %d", p.currentToken.Kind, p.currentToken.Line, p.currentToken.Column)
+		return nil, p.unexpected(ErrBadTimestamp, token.TIMESTAMP)
 	}
 	start, err := parseSRTTime(p.currentToken.Literal)
 	if err != nil {
-		return nil, err
+		return nil, p.unexpected(ErrBadTimestamp)
 	}
 	c.Start = start
 	p.readToken()
 
 	// Arrow
 	if p.currentToken.Kind != token.ARROW {
-		return nil, fmt.Errorf("expected ARROW, got %s at line %d, column %d", p.currentToken.Kind, p.currentToken.Line, p.currentToken.Column)
+		return nil, p.unexpected(ErrMissingArrow, token.ARROW)
 	}
 	p.readToken()
 
 	// End
 	if p.currentToken.Kind != token.TIMESTAMP {
-		return nil, fmt.Errorf("expected TIMESTAMP, got %s at line %d, column %d", p.currentToken.Kind, p.currentToken.Line, p.currentToken.Column)
+		return nil, p.unexpected(ErrBadTimestamp, token.TIMESTAMP)
 	}
 	end, err := parseSRTTime(p.currentToken.Literal)
 	if err != nil {
-		return nil, err
+		return nil, p.unexpected(ErrBadTimestamp)
 	}
 	c.End = end
 	p.readToken()
 
 	// Line feed
 	if p.currentToken.Kind != token.LF {
-		return nil, fmt.Errorf("expected LF, got %s at line %d, column %d", p.currentToken.Kind, p.currentToken.Line, p.currentToken.Column)
+		return nil, p.unexpected(ErrUnexpectedToken, token.LF)
 	}
 	p.readToken()
 
 	// Text
 	var textLines []string
 	if p.currentToken.Kind != token.TEXT {
-		return nil, fmt.Errorf("expected TEXT, got %s at line %d, column %d",
-			p.currentToken.Kind, p.currentToken.Line, p.currentToken.Column)
+		return nil, p.unexpected(ErrEmptyText, token.TEXT)
 	}
 
 	for p.currentToken.Kind == token.TEXT || p.currentToken.Kind == token.LF {
@@ -119,7 +202,7 @@ func (p *Parser) parseCue() (*model.Cue, error) {
 
 	// End of cue
 	if p.currentToken.Kind != token.EOC && p.currentToken.Kind != token.EOF {
-		return nil, fmt.Errorf("expected EOC, got %s at line %d, column %d", p.currentToken.Kind, p.currentToken.Line, p.currentToken.Column)
+		return nil, p.unexpected(ErrUnexpectedToken, token.EOC, token.EOF)
 	}
 
 	p.readToken()
@@ -127,13 +210,34 @@ func (p *Parser) parseCue() (*model.Cue, error) {
 	return &c, nil
 }
 
+// unexpected returns an *Error describing the current token. When expected
+// is empty, the error reports the token itself as invalid.
+func (p *Parser) unexpected(cause error, expected ...token.TokenKind) *Error {
+	actual := p.currentToken
+	actual.Offset = p.lexer.OriginalOffset(actual.Offset)
+	return &Error{
+		Line:     actual.Line,
+		Column:   actual.Column,
+		Offset:   actual.Offset,
+		Expected: expected,
+		Actual:   actual,
+		Cue:      p.cue,
+		Source:   p.lexer.Line(p.currentToken.Line),
+		Err:      cause,
+	}
+}
+
 // parseSRTTime parses a time string in the format "HH:MM:SS,mmm" and returns a model.Duration.
+// Minutes and seconds must be below 60.
 func parseSRTTime(s string) (model.Duration, error) {
 	var h, m, sec, ms int
 	_, err := fmt.Sscanf(s, "%02d:%02d:%02d,%03d", &h, &m, &sec, &ms)
 	if err != nil {
 		return 0, err
 	}
+	if m > 59 || sec > 59 {
+		return 0, ErrBadTimestamp
+	}
 
 	d := time.Duration(h)*time.Hour +
 		time.Duration(m)*time.Minute +
@@ -15,8 +15,8 @@ func TestNew(t *testing.T) {
 
 	p := New(l)
 
-	assert.Equal(t, token.NewToken(token.INDEX, "11", 1, 1), p.currentToken, "Expected current token to be INDEX (11), got %s (%s)", p.currentToken.Kind, p.currentToken.Literal)
-	assert.Equal(t, token.NewToken(token.LF, "\n", 2, 0), p.nextToken, "Expected  next token to be LF (\n), got %s (%s)", p.currentToken.Kind, p.currentToken.Literal)
+	assert.Equal(t, token.NewToken(token.INDEX, "11", 1, 1, 0), p.currentToken, "Expected current token to be INDEX (11), got %s (%s)", p.currentToken.Kind, p.currentToken.Literal)
+	assert.Equal(t, token.NewToken(token.LF, "\n", 2, 0, 2), p.nextToken, "Expected  next token to be LF (\n), got %s (%s)", p.currentToken.Kind, p.currentToken.Literal)
 }
 
 func TestParse(t *testing.T) {
@@ -105,7 +105,7 @@ Hello
 2
 00:00:02,000 --> 00:00:03,000
 World`,
-			err: "expected EOC, got INDEX at line 4, column 1",
+			err: "expected EOC or EOF, got INDEX at line 4, column 1",
 		},
 		{
 			input: `1
@@ -167,3 +167,96 @@ First line
 		}
 	}
 }
+
+func TestParseLenient(t *testing.T) {
+	input := `1
+00:00:01,000 --> 00:00:02,000
+First
+
+2
+00:00:03,000 test
+Broken arrow
+
+3
+00:00:05,000 --> 00:00:06,000
+Third
+
+garbage line
+another one
+
+00:00:07,000 --> 00:00:08,000
+Missing index
+
+5
+00:00:09,000 --> 00:00:10,000
+Fifth
+6
+00:00:11,000 --> 00:00:12,000
+Sixth`
+
+	l, err := lexer.New(input)
+	assert.NoError(t, err, "Expected no error from New")
+
+	p := New(l)
+	cues, diagnostics := p.ParseLenient()
+
+	texts := make([]string, len(cues))
+	for i, c := range cues {
+		texts[i] = c.Text
+	}
+	assert.Equal(t, []string{"First", "Third", "Missing index", "Sixth"}, texts)
+	assert.Equal(t, 3, cues[2].Index, "Expected cue without index to be numbered after the previous cue, got %d", cues[2].Index)
+
+	if assert.Len(t, diagnostics, 3) {
+		assert.EqualError(t, diagnostics[0].Err, "expected ARROW, got TEXT at line 6, column 14")
+		assert.Equal(t, 5, diagnostics[0].StartLine)
+		assert.Equal(t, 8, diagnostics[0].EndLine)
+
+		assert.EqualError(t, diagnostics[1].Err, "expected INDEX, got TEXT at line 13, column 1")
+		assert.Equal(t, 13, diagnostics[1].StartLine)
+		assert.Equal(t, 15, diagnostics[1].EndLine)
+
+		assert.EqualError(t, diagnostics[2].Err, "expected EOC or EOF, got INDEX at line 22, column 1")
+		assert.Equal(t, 19, diagnostics[2].StartLine)
+		assert.Equal(t, 21, diagnostics[2].EndLine)
+	}
+}
+
+func TestParseLenientValidInput(t *testing.T) {
+	input := "1\n00:00:01,000 --> 00:00:02,000\nFirst\n\n2\n00:00:03,000 --> 00:00:04,000\nSecond"
+	l, _ := lexer.New(input)
+	p := New(l)
+	cues, diagnostics := p.ParseLenient()
+
+	assert.Len(t, cues, 2)
+	assert.Empty(t, diagnostics)
+}
+
+func TestParseLenientUnspacedArrow(t *testing.T) {
+	input := "1\n00:00:01,000-->00:00:02,000\nBad\n\n2\n00:00:03,000 --> 00:00:04,000\nGood\n"
+	l, _ := lexer.New(input)
+	p := New(l)
+	cues, diagnostics := p.ParseLenient()
+
+	if assert.Len(t, cues, 1) {
+		assert.Equal(t, "Good", cues[0].Text)
+	}
+	if assert.Len(t, diagnostics, 1) {
+		assert.Equal(t, 1, diagnostics[0].StartLine)
+		assert.Equal(t, 4, diagnostics[0].EndLine)
+	}
+}
+
+func TestNewFromStringRepaired(t *testing.T) {
+	p, diagnostics := NewFromStringRepaired("1\n00:00:01,000 --> 00:00:02,000\nD\xe9j\xe0 vu\n")
+	cues, err := p.Parse()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "D\ufffdj\ufffd vu", cues[0].Text)
+	if assert.Len(t, diagnostics, 1) {
+		assert.ErrorIs(t, diagnostics[0].Err, ErrInvalidUTF8)
+		assert.EqualError(t, diagnostics[0].Err, "input string is not valid UTF-8 at line 3, column 2")
+		assert.Equal(t, 3, diagnostics[0].StartLine)
+		assert.Equal(t, 3, diagnostics[0].EndLine)
+	}
+}
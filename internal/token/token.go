@@ -7,6 +7,7 @@ type Token struct {
 	Literal string
 	Line    int
 	Column  int
+	Offset  int
 }
 
 const (
@@ -20,11 +21,12 @@ const (
 	EOF       TokenKind = "EOF"
 )
 
-func NewToken(kind TokenKind, literal string, line, column int) Token {
+func NewToken(kind TokenKind, literal string, line, column, offset int) Token {
 	return Token{
 		Kind:    kind,
 		Literal: literal,
 		Line:    line,
 		Column:  column,
+		Offset:  offset,
 	}
 }
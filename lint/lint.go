@@ -0,0 +1,123 @@
+// Package lint checks subtitle tracks against configurable rules, such as
+// those of broadcaster delivery specifications, and fixes what can be fixed
+// safely.
+package lint
+
+import (
+	"sort"
+	"time"
+
+	"github.com/florentsorel/srt/model"
+)
+
+// Severity is the importance of a finding.
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	}
+	return "unknown"
+}
+
+// Finding is a problem reported by a rule.
+type Finding struct {
+	Rule     string
+	Severity Severity
+	// Cue is the 1-based position of the cue in Subtitles.Items.
+	Cue        int
+	Message    string
+	Suggestion string
+	// Fixable reports whether the rule can fix the problem in Fix mode.
+	Fixable bool
+}
+
+// Rule checks a track and reports findings.
+type Rule interface {
+	Name() string
+	Check(s model.Subtitles) []Finding
+}
+
+// Fixer is a Rule that can also fix the problems it reports. Fix must only
+// apply changes that are safe without human review.
+type Fixer interface {
+	Rule
+	Fix(s model.Subtitles) model.Subtitles
+}
+
+// Config holds the thresholds of the rules returned by Rules. Zero values
+// disable the corresponding rule.
+type Config struct {
+	MinGap        time.Duration
+	MinDuration   time.Duration
+	MaxDuration   time.Duration
+	MaxLines      int
+	MaxLineLength int
+}
+
+// Rules returns the rules configured by cfg. The rules without threshold are
+// always included. They are ordered so that Fix applies structural fixes
+// (removing empty cues, sorting, renumbering) before timing fixes.
+func Rules(cfg Config) []Rule {
+	rules := []Rule{
+		EmptyText(),
+		StartOrder(),
+		IndexSequence(),
+		EndBeforeStart(),
+		Overlap(),
+	}
+
+	if cfg.MinGap > 0 {
+		rules = append(rules, MinGap(cfg.MinGap))
+	}
+	if cfg.MinDuration > 0 {
+		rules = append(rules, MinDuration(cfg.MinDuration))
+	}
+	if cfg.MaxDuration > 0 {
+		rules = append(rules, MaxDuration(cfg.MaxDuration))
+	}
+	if cfg.MaxLines > 0 {
+		rules = append(rules, MaxLines(cfg.MaxLines))
+	}
+	if cfg.MaxLineLength > 0 {
+		rules = append(rules, MaxLineLength(cfg.MaxLineLength))
+	}
+
+	return rules
+}
+
+// Run checks s against the given rules and returns the findings ordered by
+// cue, then by rule order.
+func Run(s model.Subtitles, rules ...Rule) []Finding {
+	var findings []Finding
+	for _, rule := range rules {
+		findings = append(findings, rule.Check(s)...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Cue < findings[j].Cue
+	})
+	return findings
+}
+
+// Fix applies the fixes of the rules that implement Fixer, in order, and
+// returns the fixed track along with the findings that remain.
+func Fix(s model.Subtitles, rules ...Rule) (model.Subtitles, []Finding) {
+	for _, rule := range rules {
+		if fixer, ok := rule.(Fixer); ok {
+			s = fixer.Fix(s)
+		}
+	}
+	return s, Run(s, rules...)
+}
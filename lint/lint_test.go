@@ -0,0 +1,81 @@
+package lint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/florentsorel/srt/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeverity_String(t *testing.T) {
+	assert.Equal(t, "info", Info.String())
+	assert.Equal(t, "warning", Warning.String())
+	assert.Equal(t, "error", Error.String())
+	assert.Equal(t, "unknown", Severity(42).String())
+}
+
+func TestRules(t *testing.T) {
+	names := func(rules []Rule) []string {
+		var names []string
+		for _, rule := range rules {
+			names = append(names, rule.Name())
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"empty-text", "start-order", "index-sequence", "end-before-start", "overlap"}, names(Rules(Config{})))
+
+	rules := Rules(Config{
+		MinGap:        80 * time.Millisecond,
+		MinDuration:   time.Second,
+		MaxDuration:   7 * time.Second,
+		MaxLines:      2,
+		MaxLineLength: 42,
+	})
+	assert.Equal(t, []string{
+		"empty-text", "start-order", "index-sequence", "end-before-start", "overlap",
+		"min-gap", "min-duration", "max-duration", "max-lines", "max-line-length",
+	}, names(rules))
+}
+
+func TestRun(t *testing.T) {
+	subtitles := model.Subtitles{Items: []model.Cue{
+		{Index: 1, Start: model.Duration(time.Second), End: model.Duration(3 * time.Second), Text: "First"},
+		{Index: 3, Start: model.Duration(2 * time.Second), End: model.Duration(4 * time.Second), Text: ""},
+	}}
+
+	findings := Run(subtitles, Rules(Config{})...)
+	assert.Equal(t, []Finding{
+		{Rule: "overlap", Severity: Error, Cue: 1, Message: "cue ends at 00:00:03,000, after the next cue starts at 00:00:02,000", Suggestion: "end the cue at 00:00:02,000", Fixable: true},
+		{Rule: "empty-text", Severity: Error, Cue: 2, Message: "cue has no text", Suggestion: "remove the cue", Fixable: true},
+		{Rule: "index-sequence", Severity: Warning, Cue: 2, Message: "index 3, expected 2", Suggestion: "renumber the cue to 2", Fixable: true},
+	}, findings)
+
+	assert.Empty(t, Run(model.Subtitles{}, Rules(Config{})...))
+}
+
+func TestFix(t *testing.T) {
+	subtitles := model.Subtitles{Items: []model.Cue{
+		{Index: 1, Start: model.Duration(5 * time.Second), End: model.Duration(6 * time.Second), Text: "Third"},
+		{Index: 2, Start: model.Duration(time.Second), End: model.Duration(3 * time.Second), Text: "First"},
+		{Index: 3, Start: model.Duration(2 * time.Second), End: model.Duration(4 * time.Second), Text: " "},
+		{Index: 4, Start: model.Duration(2500 * time.Millisecond), End: model.Duration(4 * time.Second), Text: "Second"},
+		{Index: 5, Start: model.Duration(10 * time.Second), End: model.Duration(20 * time.Second), Text: "Too long"},
+	}}
+
+	fixed, findings := Fix(subtitles, Rules(Config{MinGap: 100 * time.Millisecond, MaxDuration: 7 * time.Second})...)
+
+	assert.Equal(t, []model.Cue{
+		{Index: 1, Start: model.Duration(time.Second), End: model.Duration(2400 * time.Millisecond), Text: "First"},
+		{Index: 2, Start: model.Duration(2500 * time.Millisecond), End: model.Duration(4 * time.Second), Text: "Second"},
+		{Index: 3, Start: model.Duration(5 * time.Second), End: model.Duration(6 * time.Second), Text: "Third"},
+		{Index: 4, Start: model.Duration(10 * time.Second), End: model.Duration(20 * time.Second), Text: "Too long"},
+	}, fixed.Items)
+	assert.Equal(t, []Finding{
+		{Rule: "max-duration", Severity: Warning, Cue: 4, Message: "cue lasts 10s, above 7s", Suggestion: "split the cue or shorten it"},
+	}, findings)
+
+	assert.Equal(t, "Third", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+	assert.Equal(t, model.Duration(3*time.Second), subtitles.Items[1].End, "Expected original subtitles to be unchanged")
+}
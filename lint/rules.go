@@ -0,0 +1,349 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/florentsorel/srt/model"
+)
+
+// copyItems returns a copy of the cues of s, so that fixes never modify the
+// track they are given.
+func copyItems(s model.Subtitles) []model.Cue {
+	items := make([]model.Cue, len(s.Items))
+	copy(items, s.Items)
+	return items
+}
+
+type emptyText struct{}
+
+// EmptyText reports cues without visible text. Fix removes them.
+func EmptyText() Rule { return emptyText{} }
+
+func (emptyText) Name() string { return "empty-text" }
+
+func (r emptyText) Check(s model.Subtitles) []Finding {
+	var findings []Finding
+	for i, cue := range s.Items {
+		if strings.TrimSpace(cue.Text) == "" {
+			findings = append(findings, Finding{
+				Rule:       r.Name(),
+				Severity:   Error,
+				Cue:        i + 1,
+				Message:    "cue has no text",
+				Suggestion: "remove the cue",
+				Fixable:    true,
+			})
+		}
+	}
+	return findings
+}
+
+func (emptyText) Fix(s model.Subtitles) model.Subtitles {
+	var indices []int
+	for i, cue := range s.Items {
+		if strings.TrimSpace(cue.Text) == "" {
+			indices = append(indices, i)
+		}
+	}
+	if len(indices) == 0 {
+		return s
+	}
+	return s.RemoveAtIndices(indices)
+}
+
+type startOrder struct{}
+
+// StartOrder reports cues starting before the previous one. Fix sorts the
+// cues by start time.
+func StartOrder() Rule { return startOrder{} }
+
+func (startOrder) Name() string { return "start-order" }
+
+func (r startOrder) Check(s model.Subtitles) []Finding {
+	var findings []Finding
+	for i := 1; i < len(s.Items); i++ {
+		if s.Items[i].Start < s.Items[i-1].Start {
+			findings = append(findings, Finding{
+				Rule:       r.Name(),
+				Severity:   Error,
+				Cue:        i + 1,
+				Message:    fmt.Sprintf("cue starts at %s, before the previous cue at %s", s.Items[i].Start.SRTString(), s.Items[i-1].Start.SRTString()),
+				Suggestion: "sort cues by start time",
+				Fixable:    true,
+			})
+		}
+	}
+	return findings
+}
+
+func (startOrder) Fix(s model.Subtitles) model.Subtitles {
+	return s.Sort()
+}
+
+type indexSequence struct{}
+
+// IndexSequence reports cue indexes that are not 1, 2, 3... in order,
+// including duplicates. Fix renumbers the cues.
+func IndexSequence() Rule { return indexSequence{} }
+
+func (indexSequence) Name() string { return "index-sequence" }
+
+func (r indexSequence) Check(s model.Subtitles) []Finding {
+	var findings []Finding
+	seen := make(map[int]bool, len(s.Items))
+	for i, cue := range s.Items {
+		message := ""
+		switch {
+		case seen[cue.Index]:
+			message = fmt.Sprintf("duplicate index %d", cue.Index)
+		case cue.Index != i+1:
+			message = fmt.Sprintf("index %d, expected %d", cue.Index, i+1)
+		}
+		seen[cue.Index] = true
+
+		if message != "" {
+			findings = append(findings, Finding{
+				Rule:       r.Name(),
+				Severity:   Warning,
+				Cue:        i + 1,
+				Message:    message,
+				Suggestion: fmt.Sprintf("renumber the cue to %d", i+1),
+				Fixable:    true,
+			})
+		}
+	}
+	return findings
+}
+
+func (indexSequence) Fix(s model.Subtitles) model.Subtitles {
+	return s.Renumber()
+}
+
+type endBeforeStart struct{}
+
+// EndBeforeStart reports cues that do not end after they start.
+func EndBeforeStart() Rule { return endBeforeStart{} }
+
+func (endBeforeStart) Name() string { return "end-before-start" }
+
+func (r endBeforeStart) Check(s model.Subtitles) []Finding {
+	var findings []Finding
+	for i, cue := range s.Items {
+		if cue.End <= cue.Start {
+			findings = append(findings, Finding{
+				Rule:       r.Name(),
+				Severity:   Error,
+				Cue:        i + 1,
+				Message:    fmt.Sprintf("cue ends at %s, not after its start at %s", cue.End.SRTString(), cue.Start.SRTString()),
+				Suggestion: "set the end time after the start time",
+			})
+		}
+	}
+	return findings
+}
+
+type overlap struct{}
+
+// Overlap reports cues that end after the next cue starts. Fix ends such
+// cues when the next one starts, provided they still end after they start.
+func Overlap() Rule { return overlap{} }
+
+func (overlap) Name() string { return "overlap" }
+
+func (r overlap) Check(s model.Subtitles) []Finding {
+	var findings []Finding
+	for i := 0; i+1 < len(s.Items); i++ {
+		cue, next := s.Items[i], s.Items[i+1]
+		if cue.End > next.Start {
+			findings = append(findings, Finding{
+				Rule:       r.Name(),
+				Severity:   Error,
+				Cue:        i + 1,
+				Message:    fmt.Sprintf("cue ends at %s, after the next cue starts at %s", cue.End.SRTString(), next.Start.SRTString()),
+				Suggestion: fmt.Sprintf("end the cue at %s", next.Start.SRTString()),
+				Fixable:    next.Start > cue.Start,
+			})
+		}
+	}
+	return findings
+}
+
+func (overlap) Fix(s model.Subtitles) model.Subtitles {
+	items := copyItems(s)
+	for i := 0; i+1 < len(items); i++ {
+		if items[i].End > items[i+1].Start && items[i+1].Start > items[i].Start {
+			items[i].End = items[i+1].Start
+		}
+	}
+	return model.Subtitles{Items: items}
+}
+
+type minGap struct {
+	gap time.Duration
+}
+
+// MinGap reports consecutive cues separated by less than gap. Overlapping
+// cues are left to the Overlap rule. Fix ends such cues earlier to restore
+// the gap, provided they still end after they start.
+func MinGap(gap time.Duration) Rule { return minGap{gap: gap} }
+
+func (minGap) Name() string { return "min-gap" }
+
+func (r minGap) Check(s model.Subtitles) []Finding {
+	var findings []Finding
+	for i := 0; i+1 < len(s.Items); i++ {
+		cue, next := s.Items[i], s.Items[i+1]
+		gap := time.Duration(next.Start - cue.End)
+		if gap >= 0 && gap < r.gap {
+			end := next.Start.Add(-r.gap)
+			findings = append(findings, Finding{
+				Rule:       r.Name(),
+				Severity:   Warning,
+				Cue:        i + 1,
+				Message:    fmt.Sprintf("gap of %v to the next cue, below %v", gap, r.gap),
+				Suggestion: fmt.Sprintf("end the cue at %s", end.SRTString()),
+				Fixable:    end > cue.Start,
+			})
+		}
+	}
+	return findings
+}
+
+func (r minGap) Fix(s model.Subtitles) model.Subtitles {
+	items := copyItems(s)
+	for i := 0; i+1 < len(items); i++ {
+		gap := time.Duration(items[i+1].Start - items[i].End)
+		end := items[i+1].Start.Add(-r.gap)
+		if gap >= 0 && gap < r.gap && end > items[i].Start {
+			items[i].End = end
+		}
+	}
+	return model.Subtitles{Items: items}
+}
+
+type minDuration struct {
+	min time.Duration
+}
+
+// MinDuration reports cues displayed for less than min. Fix extends them as
+// far as possible without overlapping the next cue.
+func MinDuration(min time.Duration) Rule { return minDuration{min: min} }
+
+func (minDuration) Name() string { return "min-duration" }
+
+func (r minDuration) Check(s model.Subtitles) []Finding {
+	var findings []Finding
+	for i, cue := range s.Items {
+		d := time.Duration(cue.End - cue.Start)
+		if d > 0 && d < r.min {
+			findings = append(findings, Finding{
+				Rule:       r.Name(),
+				Severity:   Warning,
+				Cue:        i + 1,
+				Message:    fmt.Sprintf("cue lasts %v, below %v", d, r.min),
+				Suggestion: fmt.Sprintf("end the cue at %s", cue.Start.Add(r.min).SRTString()),
+				Fixable:    true,
+			})
+		}
+	}
+	return findings
+}
+
+func (r minDuration) Fix(s model.Subtitles) model.Subtitles {
+	items := copyItems(s)
+	for i := range items {
+		d := time.Duration(items[i].End - items[i].Start)
+		if d <= 0 || d >= r.min {
+			continue
+		}
+
+		end := items[i].Start.Add(r.min)
+		if i+1 < len(items) && items[i+1].Start < end {
+			end = items[i+1].Start
+		}
+		if end > items[i].End {
+			items[i].End = end
+		}
+	}
+	return model.Subtitles{Items: items}
+}
+
+type maxDuration struct {
+	max time.Duration
+}
+
+// MaxDuration reports cues displayed for more than max.
+func MaxDuration(max time.Duration) Rule { return maxDuration{max: max} }
+
+func (maxDuration) Name() string { return "max-duration" }
+
+func (r maxDuration) Check(s model.Subtitles) []Finding {
+	var findings []Finding
+	for i, cue := range s.Items {
+		if d := time.Duration(cue.End - cue.Start); d > r.max {
+			findings = append(findings, Finding{
+				Rule:       r.Name(),
+				Severity:   Warning,
+				Cue:        i + 1,
+				Message:    fmt.Sprintf("cue lasts %v, above %v", d, r.max),
+				Suggestion: "split the cue or shorten it",
+			})
+		}
+	}
+	return findings
+}
+
+type maxLines struct {
+	max int
+}
+
+// MaxLines reports cues with more than max lines of text.
+func MaxLines(max int) Rule { return maxLines{max: max} }
+
+func (maxLines) Name() string { return "max-lines" }
+
+func (r maxLines) Check(s model.Subtitles) []Finding {
+	var findings []Finding
+	for i, cue := range s.Items {
+		if n := len(strings.Split(cue.Text, "\n")); n > r.max {
+			findings = append(findings, Finding{
+				Rule:       r.Name(),
+				Severity:   Warning,
+				Cue:        i + 1,
+				Message:    fmt.Sprintf("cue has %d lines, above %d", n, r.max),
+				Suggestion: "rewrap the text or split the cue",
+			})
+		}
+	}
+	return findings
+}
+
+type maxLineLength struct {
+	max int
+}
+
+// MaxLineLength reports cues with a line wider than max columns, as
+// measured by model.TextWidth, formatting tags excluded.
+func MaxLineLength(max int) Rule { return maxLineLength{max: max} }
+
+func (maxLineLength) Name() string { return "max-line-length" }
+
+func (r maxLineLength) Check(s model.Subtitles) []Finding {
+	var findings []Finding
+	for i, cue := range s.Items {
+		for j, line := range strings.Split(cue.PlainText(), "\n") {
+			if n := model.TextWidth(line); n > r.max {
+				findings = append(findings, Finding{
+					Rule:       r.Name(),
+					Severity:   Warning,
+					Cue:        i + 1,
+					Message:    fmt.Sprintf("line %d is %d columns wide, above %d", j+1, n, r.max),
+					Suggestion: "rewrap the text",
+				})
+			}
+		}
+	}
+	return findings
+}
@@ -0,0 +1,245 @@
+package lint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/florentsorel/srt/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// cues returns the 1-based cue ordinals of the findings.
+func cues(findings []Finding) []int {
+	var ordinals []int
+	for _, f := range findings {
+		ordinals = append(ordinals, f.Cue)
+	}
+	return ordinals
+}
+
+func TestRules_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     Rule
+		items    []model.Cue
+		expected []int
+	}{
+		{
+			name: "empty text",
+			rule: EmptyText(),
+			items: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(time.Second), Text: "Text"},
+				{Index: 2, Start: model.Duration(2 * time.Second), End: model.Duration(3 * time.Second), Text: " \n "},
+			},
+			expected: []int{2},
+		},
+		{
+			name: "start order",
+			rule: StartOrder(),
+			items: []model.Cue{
+				{Index: 1, Start: model.Duration(2 * time.Second), End: model.Duration(3 * time.Second), Text: "Second"},
+				{Index: 2, Start: 0, End: model.Duration(time.Second), Text: "First"},
+				{Index: 3, Start: model.Duration(4 * time.Second), End: model.Duration(5 * time.Second), Text: "Third"},
+			},
+			expected: []int{2},
+		},
+		{
+			name: "index sequence",
+			rule: IndexSequence(),
+			items: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(time.Second), Text: "First"},
+				{Index: 1, Start: model.Duration(2 * time.Second), End: model.Duration(3 * time.Second), Text: "Duplicate"},
+				{Index: 3, Start: model.Duration(4 * time.Second), End: model.Duration(5 * time.Second), Text: "Third"},
+				{Index: 7, Start: model.Duration(6 * time.Second), End: model.Duration(7 * time.Second), Text: "Gap"},
+			},
+			expected: []int{2, 4},
+		},
+		{
+			name: "end before start",
+			rule: EndBeforeStart(),
+			items: []model.Cue{
+				{Index: 1, Start: model.Duration(time.Second), End: model.Duration(time.Second), Text: "Zero"},
+				{Index: 2, Start: model.Duration(3 * time.Second), End: model.Duration(2 * time.Second), Text: "Negative"},
+				{Index: 3, Start: model.Duration(4 * time.Second), End: model.Duration(5 * time.Second), Text: "Fine"},
+			},
+			expected: []int{1, 2},
+		},
+		{
+			name: "overlap",
+			rule: Overlap(),
+			items: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(2 * time.Second), Text: "First"},
+				{Index: 2, Start: model.Duration(time.Second), End: model.Duration(3 * time.Second), Text: "Second"},
+				{Index: 3, Start: model.Duration(3 * time.Second), End: model.Duration(4 * time.Second), Text: "Third"},
+			},
+			expected: []int{1},
+		},
+		{
+			name: "min gap",
+			rule: MinGap(100 * time.Millisecond),
+			items: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(time.Second), Text: "First"},
+				{Index: 2, Start: model.Duration(1050 * time.Millisecond), End: model.Duration(2 * time.Second), Text: "Second"},
+				{Index: 3, Start: model.Duration(2100 * time.Millisecond), End: model.Duration(3 * time.Second), Text: "Third"},
+			},
+			expected: []int{1},
+		},
+		{
+			name: "min duration",
+			rule: MinDuration(time.Second),
+			items: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(500 * time.Millisecond), Text: "Short"},
+				{Index: 2, Start: model.Duration(2 * time.Second), End: model.Duration(3 * time.Second), Text: "Fine"},
+			},
+			expected: []int{1},
+		},
+		{
+			name: "max duration",
+			rule: MaxDuration(7 * time.Second),
+			items: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(7 * time.Second), Text: "Fine"},
+				{Index: 2, Start: model.Duration(8 * time.Second), End: model.Duration(16 * time.Second), Text: "Long"},
+			},
+			expected: []int{2},
+		},
+		{
+			name: "max lines",
+			rule: MaxLines(2),
+			items: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(time.Second), Text: "One\nTwo"},
+				{Index: 2, Start: model.Duration(2 * time.Second), End: model.Duration(3 * time.Second), Text: "One\nTwo\nThree"},
+			},
+			expected: []int{2},
+		},
+		{
+			name: "max line length",
+			rule: MaxLineLength(10),
+			items: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(time.Second), Text: "<i>Déjà vu là</i>"},
+				{Index: 2, Start: model.Duration(2 * time.Second), End: model.Duration(3 * time.Second), Text: "Short\nA bit too long"},
+			},
+			expected: []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := tt.rule.Check(model.Subtitles{Items: tt.items})
+			assert.Equal(t, tt.expected, cues(findings))
+			for _, f := range findings {
+				assert.Equal(t, tt.rule.Name(), f.Rule)
+				assert.NotEmpty(t, f.Message)
+				assert.NotEmpty(t, f.Suggestion)
+			}
+		})
+	}
+}
+
+func TestRules_Fix(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     Fixer
+		items    []model.Cue
+		expected []model.Cue
+	}{
+		{
+			name: "overlap trims the end",
+			rule: Overlap().(Fixer),
+			items: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(2 * time.Second), Text: "First"},
+				{Index: 2, Start: model.Duration(time.Second), End: model.Duration(3 * time.Second), Text: "Second"},
+			},
+			expected: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(time.Second), Text: "First"},
+				{Index: 2, Start: model.Duration(time.Second), End: model.Duration(3 * time.Second), Text: "Second"},
+			},
+		},
+		{
+			name: "overlap keeps cues starting together",
+			rule: Overlap().(Fixer),
+			items: []model.Cue{
+				{Index: 1, Start: model.Duration(time.Second), End: model.Duration(2 * time.Second), Text: "First"},
+				{Index: 2, Start: model.Duration(time.Second), End: model.Duration(3 * time.Second), Text: "Second"},
+			},
+			expected: []model.Cue{
+				{Index: 1, Start: model.Duration(time.Second), End: model.Duration(2 * time.Second), Text: "First"},
+				{Index: 2, Start: model.Duration(time.Second), End: model.Duration(3 * time.Second), Text: "Second"},
+			},
+		},
+		{
+			name: "min gap pulls the end back",
+			rule: MinGap(100 * time.Millisecond).(Fixer),
+			items: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(time.Second), Text: "First"},
+				{Index: 2, Start: model.Duration(1050 * time.Millisecond), End: model.Duration(2 * time.Second), Text: "Second"},
+			},
+			expected: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(950 * time.Millisecond), Text: "First"},
+				{Index: 2, Start: model.Duration(1050 * time.Millisecond), End: model.Duration(2 * time.Second), Text: "Second"},
+			},
+		},
+		{
+			name: "min duration extends up to the next cue",
+			rule: MinDuration(time.Second).(Fixer),
+			items: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(500 * time.Millisecond), Text: "First"},
+				{Index: 2, Start: model.Duration(800 * time.Millisecond), End: model.Duration(900 * time.Millisecond), Text: "Second"},
+			},
+			expected: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(800 * time.Millisecond), Text: "First"},
+				{Index: 2, Start: model.Duration(800 * time.Millisecond), End: model.Duration(1800 * time.Millisecond), Text: "Second"},
+			},
+		},
+		{
+			name: "empty text removes the cue",
+			rule: EmptyText().(Fixer),
+			items: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(time.Second), Text: ""},
+				{Index: 2, Start: model.Duration(2 * time.Second), End: model.Duration(3 * time.Second), Text: "Second"},
+			},
+			expected: []model.Cue{
+				{Index: 1, Start: model.Duration(2 * time.Second), End: model.Duration(3 * time.Second), Text: "Second"},
+			},
+		},
+		{
+			name: "start order sorts the cues",
+			rule: StartOrder().(Fixer),
+			items: []model.Cue{
+				{Index: 1, Start: model.Duration(2 * time.Second), End: model.Duration(3 * time.Second), Text: "Second"},
+				{Index: 2, Start: 0, End: model.Duration(time.Second), Text: "First"},
+			},
+			expected: []model.Cue{
+				{Index: 2, Start: 0, End: model.Duration(time.Second), Text: "First"},
+				{Index: 1, Start: model.Duration(2 * time.Second), End: model.Duration(3 * time.Second), Text: "Second"},
+			},
+		},
+		{
+			name: "index sequence renumbers",
+			rule: IndexSequence().(Fixer),
+			items: []model.Cue{
+				{Index: 4, Start: 0, End: model.Duration(time.Second), Text: "First"},
+				{Index: 4, Start: model.Duration(2 * time.Second), End: model.Duration(3 * time.Second), Text: "Second"},
+			},
+			expected: []model.Cue{
+				{Index: 1, Start: 0, End: model.Duration(time.Second), Text: "First"},
+				{Index: 2, Start: model.Duration(2 * time.Second), End: model.Duration(3 * time.Second), Text: "Second"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := append([]model.Cue(nil), tt.items...)
+			fixed := tt.rule.Fix(model.Subtitles{Items: tt.items})
+			assert.Equal(t, tt.expected, fixed.Items)
+			assert.Equal(t, original, tt.items, "Expected original cues to be unchanged")
+		})
+	}
+}
+
+func TestRules_NotFixable(t *testing.T) {
+	for _, rule := range []Rule{EndBeforeStart(), MaxDuration(time.Second), MaxLines(1), MaxLineLength(1)} {
+		_, ok := rule.(Fixer)
+		assert.False(t, ok, "Expected %s not to be a Fixer", rule.Name())
+	}
+}
@@ -0,0 +1,207 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// BilingualLayout is how Bilingual shows the two languages of a cue.
+type BilingualLayout int
+
+const (
+	// BilingualStacked shows both languages in a single cue, the primary
+	// text above the secondary text.
+	BilingualStacked BilingualLayout = iota
+	// BilingualSplit shows the primary text at the bottom of the screen and
+	// the secondary text at the top, as two cues with the same timing.
+	BilingualSplit
+)
+
+// BilingualOptions configures Bilingual.
+type BilingualOptions struct {
+	Layout BilingualLayout
+	// SecondaryItalic shows the secondary text in italics.
+	SecondaryItalic bool
+	// SecondaryColor is the color of the secondary text, such as "#FFFF00".
+	// The text keeps its own colors when empty.
+	SecondaryColor string
+}
+
+// Bilingual returns a new Subtitles showing the cues of primary along with
+// the cues of secondary, in another language, that are displayed at the same
+// time.
+//
+// A cue is aligned with the cue of the other track it overlaps the most,
+// provided they overlap for more than half of its duration. Alignments are
+// transitive, so that a sentence split across several cues in one track is
+// shown with the single cue of the other track it translates: all aligned
+// cues are combined, from the start of the first to the end of the last,
+// their texts being joined by line breaks. Cues without counterpart are kept
+// alone. The result is sorted by Start and renumbered.
+func Bilingual(primary, secondary Subtitles, opts BilingualOptions) Subtitles {
+	n := len(primary.Items)
+	nodes := make([]Cue, 0, n+len(secondary.Items))
+	nodes = append(nodes, primary.Items...)
+	nodes = append(nodes, secondary.Items...)
+
+	parent := make([]int, len(nodes))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(i, j int) {
+		if ri, rj := find(i), find(j); ri != rj {
+			if ri > rj {
+				ri, rj = rj, ri
+			}
+			parent[rj] = ri
+		}
+	}
+
+	for i, cue := range primary.Items {
+		if j, ok := bestAlignment(cue, secondary.Items); ok {
+			union(i, n+j)
+		}
+	}
+	for j, cue := range secondary.Items {
+		if i, ok := bestAlignment(cue, primary.Items); ok {
+			union(i, n+j)
+		}
+	}
+
+	// Collect the groups of aligned cues in track order.
+	type group struct {
+		primary, secondary []Cue
+	}
+	var roots []int
+	groups := make(map[int]*group)
+	for i, cue := range nodes {
+		root := find(i)
+		g, ok := groups[root]
+		if !ok {
+			g = &group{}
+			groups[root] = g
+			roots = append(roots, root)
+		}
+		if i < n {
+			g.primary = append(g.primary, cue)
+		} else {
+			g.secondary = append(g.secondary, cue)
+		}
+	}
+
+	var items []Cue
+	for _, root := range roots {
+		g := groups[root]
+		items = append(items, composeBilingual(g.primary, g.secondary, opts)...)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Start < items[j].Start
+	})
+	renumber(items)
+
+	return Subtitles{Items: items}
+}
+
+// bestAlignment returns the position of the cue of items that c overlaps
+// the most, if the overlap lasts more than half of c.
+func bestAlignment(c Cue, items []Cue) (int, bool) {
+	best, bestOverlap := -1, Duration(0)
+	for i, other := range items {
+		if o := overlapDuration(c, other); o > bestOverlap {
+			best, bestOverlap = i, o
+		}
+	}
+	if best < 0 || bestOverlap*2 <= c.End-c.Start {
+		return 0, false
+	}
+	return best, true
+}
+
+// overlapDuration returns how long a and b are displayed together.
+func overlapDuration(a, b Cue) Duration {
+	start, end := a.Start, a.End
+	if b.Start > start {
+		start = b.Start
+	}
+	if b.End < end {
+		end = b.End
+	}
+	if end < start {
+		return 0
+	}
+	return end - start
+}
+
+// composeBilingual returns the cues showing aligned primary and secondary
+// cues, one of which may be empty.
+func composeBilingual(primary, secondary []Cue, opts BilingualOptions) []Cue {
+	all := append(append([]Cue(nil), primary...), secondary...)
+	combined := all[0]
+	for _, cue := range all[1:] {
+		if cue.Start < combined.Start {
+			combined.Start = cue.Start
+		}
+		if cue.End > combined.End {
+			combined.End = cue.End
+		}
+	}
+
+	primaryText := joinRichText(primary)
+	secondaryText := joinRichText(secondary)
+	for i := range secondaryText.Spans {
+		if opts.SecondaryItalic {
+			secondaryText.Spans[i].Italic = true
+		}
+		if opts.SecondaryColor != "" {
+			secondaryText.Spans[i].Color = opts.SecondaryColor
+		}
+	}
+
+	switch {
+	case len(secondary) == 0:
+		combined.Text = primaryText.String()
+		return []Cue{combined}
+	case len(primary) == 0:
+		combined.Text = secondaryText.String()
+		return []Cue{combined}
+	case opts.Layout == BilingualSplit:
+		top := combined
+		primaryText.Alignment = AlignDefault
+		secondaryText.Alignment = AlignTopCenter
+		combined.Text = primaryText.String()
+		top.Text = secondaryText.String()
+		return []Cue{combined, top}
+	}
+
+	stacked := RichText{Alignment: primaryText.Alignment}
+	stacked.Spans = append(stacked.Spans, primaryText.Spans...)
+	stacked.Spans = append(stacked.Spans, Span{Text: "\n"})
+	stacked.Spans = append(stacked.Spans, secondaryText.Spans...)
+	combined.Text = stacked.String()
+	return []Cue{combined}
+}
+
+// joinRichText returns the texts of cues joined by line breaks, with the
+// alignment of the first cue that has one.
+func joinRichText(cues []Cue) RichText {
+	var joined RichText
+	texts := make([]string, 0, len(cues))
+	for _, cue := range cues {
+		rich := ParseRichText(cue.Text)
+		if joined.Alignment == AlignDefault {
+			joined.Alignment = rich.Alignment
+		}
+		rich.Alignment = AlignDefault
+		texts = append(texts, strings.TrimSpace(rich.String()))
+	}
+	joined.Spans = ParseRichText(strings.Join(texts, "\n")).Spans
+	return joined
+}
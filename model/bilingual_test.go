@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBilingual_Stacked(t *testing.T) {
+	english := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "Hello!"},
+		{Index: 2, Start: Duration(4 * time.Second), End: Duration(8 * time.Second), Text: "I went to the market yesterday."},
+		{Index: 3, Start: Duration(10 * time.Second), End: Duration(11 * time.Second), Text: "Untranslated"},
+	}}
+	french := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1100 * time.Millisecond), End: Duration(2900 * time.Millisecond), Text: "Bonjour !"},
+		{Index: 2, Start: Duration(4 * time.Second), End: Duration(6 * time.Second), Text: "Je suis allé au marché"},
+		{Index: 3, Start: Duration(6 * time.Second), End: Duration(8200 * time.Millisecond), Text: "hier."},
+		{Index: 4, Start: Duration(20 * time.Second), End: Duration(21 * time.Second), Text: "Seul"},
+	}}
+
+	combined := Bilingual(english, french, BilingualOptions{SecondaryItalic: true, SecondaryColor: "#FFFF00"})
+
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "Hello!\n<i><font color=\"#FFFF00\">Bonjour !</font></i>"},
+		{Index: 2, Start: Duration(4 * time.Second), End: Duration(8200 * time.Millisecond), Text: "I went to the market yesterday.\n<i><font color=\"#FFFF00\">Je suis allé au marché\nhier.</font></i>"},
+		{Index: 3, Start: Duration(10 * time.Second), End: Duration(11 * time.Second), Text: "Untranslated"},
+		{Index: 4, Start: Duration(20 * time.Second), End: Duration(21 * time.Second), Text: "<i><font color=\"#FFFF00\">Seul</font></i>"},
+	}, combined.Items)
+
+	assert.Equal(t, "hier.", french.Items[2].Text, "Expected original subtitles to be unchanged")
+}
+
+func TestBilingual_Split(t *testing.T) {
+	english := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "Hello!"},
+		{Index: 2, Start: Duration(4 * time.Second), End: Duration(8 * time.Second), Text: "I went to the market yesterday."},
+		{Index: 3, Start: Duration(10 * time.Second), End: Duration(11 * time.Second), Text: "Untranslated"},
+	}}
+	french := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1100 * time.Millisecond), End: Duration(2900 * time.Millisecond), Text: "Bonjour !"},
+		{Index: 2, Start: Duration(4 * time.Second), End: Duration(6 * time.Second), Text: "Je suis allé au marché"},
+		{Index: 3, Start: Duration(6 * time.Second), End: Duration(8200 * time.Millisecond), Text: "hier."},
+		{Index: 4, Start: Duration(20 * time.Second), End: Duration(21 * time.Second), Text: "Seul"},
+	}}
+
+	combined := Bilingual(english, french, BilingualOptions{Layout: BilingualSplit})
+
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "Hello!"},
+		{Index: 2, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: `{\an8}Bonjour !`},
+		{Index: 3, Start: Duration(4 * time.Second), End: Duration(8200 * time.Millisecond), Text: "I went to the market yesterday."},
+		{Index: 4, Start: Duration(4 * time.Second), End: Duration(8200 * time.Millisecond), Text: "{\\an8}Je suis allé au marché\nhier."},
+		{Index: 5, Start: Duration(10 * time.Second), End: Duration(11 * time.Second), Text: "Untranslated"},
+		{Index: 6, Start: Duration(20 * time.Second), End: Duration(21 * time.Second), Text: "Seul"},
+	}, combined.Items)
+}
+
+func TestBilingual_PrimarySplitAcrossCues(t *testing.T) {
+	primary := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(0), End: Duration(2 * time.Second), Text: "<i>It's a</i>"},
+		{Index: 2, Start: Duration(2 * time.Second), End: Duration(4 * time.Second), Text: "<i>long sentence.</i>"},
+	}}
+	secondary := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(0), End: Duration(4 * time.Second), Text: "C'est une longue phrase."},
+	}}
+
+	combined := Bilingual(primary, secondary, BilingualOptions{})
+
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(0), End: Duration(4 * time.Second), Text: "<i>It's a</i>\n<i>long sentence.</i>\nC'est une longue phrase."},
+	}, combined.Items)
+}
+
+func TestBilingual_SmallOverlapIsNotAligned(t *testing.T) {
+	primary := Subtitles{Items: []Cue{{Index: 1, Start: Duration(0), End: Duration(2 * time.Second), Text: "One"}}}
+	secondary := Subtitles{Items: []Cue{{Index: 1, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "Un"}}}
+
+	combined := Bilingual(primary, secondary, BilingualOptions{})
+
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(0), End: Duration(2 * time.Second), Text: "One"},
+		{Index: 2, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "Un"},
+	}, combined.Items)
+}
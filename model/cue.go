@@ -10,19 +10,29 @@ type Cue struct {
 	Start Duration
 	End   Duration
 	Text  string
+	// Settings holds format-specific cue settings, such as the WebVTT
+	// "line:0 align:start" suffix of the timing line. SRT cannot represent
+	// it and does not write it.
+	Settings string
+	// ID holds a cue identifier that is not a number, such as the WebVTT
+	// "intro", which Index cannot hold. SRT cannot represent it and does not
+	// write it.
+	ID string
 }
 
 // String returns the Cue in SRT format.
 func (c Cue) String() string {
-	return fmt.Sprintf("%d\n%s --> %s\n%s", c.Index, c.Start.String(), c.End.String(), c.Text)
+	return fmt.Sprintf("%d\n%s --> %s\n%s", c.Index, c.Start.SRTString(), c.End.SRTString(), c.Text)
 }
 
 // Shift returns a new Cue with Start and End times shifted by the given offset.
 func (c Cue) Shift(offset time.Duration) Cue {
 	return Cue{
-		Index: c.Index,
-		Start: c.Start.Add(offset),
-		End:   c.End.Add(offset),
-		Text:  c.Text,
+		Index:    c.Index,
+		Start:    c.Start.Add(offset),
+		End:      c.End.Add(offset),
+		Text:     c.Text,
+		Settings: c.Settings,
+		ID:       c.ID,
 	}
 }
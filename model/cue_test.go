@@ -16,7 +16,7 @@ func TestCue_String(t *testing.T) {
 		Text:  "Hello, World!",
 	}
 
-	expected := "1\n00:00:02.000 --> 00:00:05.000\nHello, World!"
+	expected := "1\n00:00:02,000 --> 00:00:05,000\nHello, World!"
 	assert.Equal(t, expected, fmt.Sprintf("%s", cue), "Expected Cue string to be:\n%s\nGot:\n%s", expected, cue.String())
 }
 
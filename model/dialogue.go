@@ -0,0 +1,127 @@
+package model
+
+import "strings"
+
+// Turn is what one speaker says in a cue.
+type Turn struct {
+	// Speaker is the name of the speaker when the turn starts with a label
+	// such as "JOHN:", and is empty otherwise.
+	Speaker string
+	// Text is the markup of the turn, without dash nor speaker label.
+	Text string
+}
+
+// DashPlacement is which turns of a dialogue start with a dash.
+type DashPlacement int
+
+const (
+	// DashEveryTurn puts a dash before every turn.
+	DashEveryTurn DashPlacement = iota
+	// DashFollowingTurns puts a dash before every turn but the first one.
+	DashFollowingTurns
+)
+
+// DialogueStyle is the way dialogue cues are written.
+type DialogueStyle struct {
+	Placement DashPlacement
+	// Dash is the dash starting a turn. Zero means the hyphen-minus "-".
+	Dash rune
+	// Space puts a space between the dash and the text.
+	Space bool
+}
+
+// turnRange is the part of the plain text of a cue holding a turn.
+type turnRange struct {
+	textRange
+	// dash is the length of the dash starting the turn, along with the
+	// white space around it.
+	dash int
+}
+
+// Turns returns the turns of the Cue. A new turn starts on every line
+// starting with a dash, or with an en dash, so that dialogues are recognized
+// whether or not their first line has a dash. A Cue that is not a dialogue
+// has a single turn, and an empty Cue has none. Formatting tags are kept in
+// the text of each turn.
+func (c Cue) Turns() []Turn {
+	rich := ParseRichText(c.Text)
+	plain := rich.PlainText()
+
+	var turns []Turn
+	for _, r := range turnRanges(plain) {
+		start := r.start + r.dash
+		speaker := ""
+		if m := speakerLabel.FindStringSubmatch(plain[start:r.end]); m != nil {
+			speaker = m[1]
+			start += len(m[0])
+		}
+
+		text := rich.slice(start, r.end)
+		text.Alignment = AlignDefault
+		turns = append(turns, Turn{Speaker: speaker, Text: text.String()})
+	}
+	return turns
+}
+
+// turnRanges returns the turns of plain text, skipping empty ones.
+func turnRanges(plain string) []turnRange {
+	var turns []turnRange
+	for _, line := range lines(plain) {
+		dash := len(dialogueDash.FindString(plain[line.start:line.end]))
+		if n := len(turns); n > 0 && dash == 0 {
+			turns[n-1].end = line.end
+			continue
+		}
+		turns = append(turns, turnRange{textRange: line, dash: dash})
+	}
+
+	nonEmpty := turns[:0]
+	for _, t := range turns {
+		if strings.TrimSpace(plain[t.start+t.dash:t.end]) != "" {
+			nonEmpty = append(nonEmpty, t)
+		}
+	}
+	return nonEmpty
+}
+
+// NormalizeDialogue returns a new Cue whose dialogue dashes follow style.
+// Cues with a single turn are returned unchanged.
+func (c Cue) NormalizeDialogue(style DialogueStyle) Cue {
+	rich := ParseRichText(c.Text)
+	plain := rich.PlainText()
+	turns := turnRanges(plain)
+	if len(turns) < 2 {
+		return c
+	}
+
+	dash := "-"
+	if style.Dash != 0 {
+		dash = string(style.Dash)
+	}
+	if style.Space {
+		dash += " "
+	}
+
+	// Replace the dashes from the last one, so that the offsets of the
+	// previous ones stay valid.
+	for i := len(turns) - 1; i >= 0; i-- {
+		prefix := dash
+		if i == 0 && style.Placement == DashFollowingTurns {
+			prefix = ""
+		}
+		rich = rich.replace(textRange{turns[i].start, turns[i].start + turns[i].dash}, prefix)
+	}
+
+	c.Text = rich.String()
+	return c
+}
+
+// NormalizeDialogue returns a new Subtitles whose dialogue dashes follow
+// style, as done by Cue.NormalizeDialogue.
+func (s Subtitles) NormalizeDialogue(style DialogueStyle) Subtitles {
+	items := make([]Cue, len(s.Items))
+	for i, cue := range s.Items {
+		items[i] = cue.NormalizeDialogue(style)
+	}
+	return Subtitles{Items: items}
+}
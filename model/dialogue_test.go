@@ -0,0 +1,153 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCue_Turns(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []Turn
+	}{
+		{
+			name:     "single speaker",
+			text:     "Hello\nthere.",
+			expected: []Turn{{Text: "Hello\nthere."}},
+		},
+		{
+			name:     "dash on both lines",
+			text:     "- Hi.\n- Hey.",
+			expected: []Turn{{Text: "Hi."}, {Text: "Hey."}},
+		},
+		{
+			name:     "dash only on second line",
+			text:     "Hi.\n-Hey.",
+			expected: []Turn{{Text: "Hi."}, {Text: "Hey."}},
+		},
+		{
+			name:     "en dash",
+			text:     "– Hi.\n– Hey.",
+			expected: []Turn{{Text: "Hi."}, {Text: "Hey."}},
+		},
+		{
+			name:     "speaker labels",
+			text:     "- JOHN: Hi.\n- MARY: Hey.",
+			expected: []Turn{{Speaker: "JOHN", Text: "Hi."}, {Speaker: "MARY", Text: "Hey."}},
+		},
+		{
+			name:     "speaker label with ampersand",
+			text:     "- TOM & JERRY: Run!\n- MARY: Hey.",
+			expected: []Turn{{Speaker: "TOM & JERRY", Text: "Run!"}, {Speaker: "MARY", Text: "Hey."}},
+		},
+		{
+			name:     "colon without space is not a label",
+			text:     "RATIO:2 to 1",
+			expected: []Turn{{Text: "RATIO:2 to 1"}},
+		},
+		{
+			name:     "turn on several lines",
+			text:     "- I was\nthinking.\n- About what?",
+			expected: []Turn{{Text: "I was\nthinking."}, {Text: "About what?"}},
+		},
+		{
+			name:     "formatting",
+			text:     `{\an8}<i>- Hi.</i>` + "\n" + `- <b>Hey.</b>`,
+			expected: []Turn{{Text: "<i>Hi.</i>"}, {Text: "<b>Hey.</b>"}},
+		},
+		{
+			name: "empty",
+			text: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cue := Cue{Index: 1, Start: 0, End: Duration(time.Second), Text: tt.text}
+			assert.Equal(t, tt.expected, cue.Turns())
+		})
+	}
+}
+
+func TestCue_TurnsSameLabelsAsRemoveHearingImpaired(t *testing.T) {
+	for _, text := range []string{"TOM & JERRY: Run!", "JOHN: Hi.", "JOHN:Hi.", "DR. SMITH: Next."} {
+		cue := Cue{Index: 1, Text: text}
+		_, report := Subtitles{Items: []Cue{cue}}.RemoveHearingImpaired(HearingImpairedOptions{})
+
+		labelled := cue.Turns()[0].Speaker != ""
+		assert.Equal(t, labelled, len(report) > 0, "Expected %q to be read the same way", text)
+	}
+}
+
+func TestCue_NormalizeDialogue(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		style    DialogueStyle
+		expected string
+	}{
+		{
+			name:     "dash on every turn with space",
+			text:     "Hi.\n-Hey.",
+			style:    DialogueStyle{Space: true},
+			expected: "- Hi.\n- Hey.",
+		},
+		{
+			name:     "dash on every turn without space",
+			text:     "- Hi.\n– Hey.",
+			expected: "-Hi.\n-Hey.",
+		},
+		{
+			name:     "dash on following turns",
+			text:     "- Hi.\n- Hey.",
+			style:    DialogueStyle{Placement: DashFollowingTurns, Space: true},
+			expected: "Hi.\n- Hey.",
+		},
+		{
+			name:     "en dash",
+			text:     "- Hi.\n- Hey.",
+			style:    DialogueStyle{Dash: '–', Space: true},
+			expected: "– Hi.\n– Hey.",
+		},
+		{
+			name:     "formatting",
+			text:     "<i>Hi.</i>\n<i>-Hey.</i>",
+			style:    DialogueStyle{Space: true},
+			expected: "<i>- Hi.</i>\n<i>- Hey.</i>",
+		},
+		{
+			name:     "turn on several lines",
+			text:     "-I was\nthinking.\n-About what?",
+			style:    DialogueStyle{Space: true},
+			expected: "- I was\nthinking.\n- About what?",
+		},
+		{
+			name:     "single turn unchanged",
+			text:     "-Hi.",
+			style:    DialogueStyle{Space: true},
+			expected: "-Hi.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cue := Cue{Index: 1, Start: 0, End: Duration(time.Second), Text: tt.text}
+			assert.Equal(t, tt.expected, cue.NormalizeDialogue(tt.style).Text)
+		})
+	}
+}
+
+func TestSubtitles_NormalizeDialogue(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: 0, End: Duration(time.Second), Text: "Hi.\n-Hey."},
+		{Index: 2, Start: Duration(time.Second), End: Duration(2 * time.Second), Text: "Alone."},
+	}}
+
+	result := subtitles.NormalizeDialogue(DialogueStyle{Space: true})
+	assert.Equal(t, "- Hi.\n- Hey.", result.Items[0].Text)
+	assert.Equal(t, "Alone.", result.Items[1].Text)
+	assert.Equal(t, "Hi.\n-Hey.", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+}
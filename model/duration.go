@@ -7,10 +7,26 @@ import (
 
 type Duration time.Duration
 
+// String returns the duration as "HH:MM:SS.mmm", prefixed with a minus sign
+// when negative. This is the WebVTT timestamp format.
 func (d Duration) String() string {
+	return d.format('.')
+}
+
+// SRTString returns the duration as "HH:MM:SS,mmm", the SRT timestamp
+// format, prefixed with a minus sign when negative.
+func (d Duration) SRTString() string {
+	return d.format(',')
+}
+
+// format returns the duration as "HH:MM:SS" followed by sep and the
+// milliseconds.
+func (d Duration) format(sep byte) string {
 	duration := time.Duration(d)
 
+	sign := ""
 	if duration < 0 {
+		sign = "-"
 		duration = -duration
 	}
 
@@ -19,7 +35,7 @@ func (d Duration) String() string {
 	seconds := int(duration.Seconds()) % 60
 	milliseconds := int(duration.Milliseconds()) % 1000
 
-	return fmt.Sprintf("%02d:%02d:%02d.%03d", hours, minutes, seconds, milliseconds)
+	return fmt.Sprintf("%s%02d:%02d:%02d%c%03d", sign, hours, minutes, seconds, sep, milliseconds)
 }
 
 func (d Duration) Add(offset time.Duration) Duration {
@@ -20,7 +20,7 @@ func TestDurationString(t *testing.T) {
 		{"minutes_seconds_millis", Duration(2*time.Minute + 3*time.Second + 7*time.Millisecond), "00:02:03.007"},
 		{"one_hour", Duration(1 * time.Hour), "01:00:00.000"},
 		{"hours_minutes_seconds_millis", Duration(10*time.Hour + 9*time.Minute + 8*time.Second + 765*time.Millisecond), "10:09:08.765"},
-		{"negative_duration", Duration(-1*time.Hour - 2*time.Minute - 3*time.Second - 4*time.Millisecond), "01:02:03.004"},
+		{"negative_duration", Duration(-1*time.Hour - 2*time.Minute - 3*time.Second - 4*time.Millisecond), "-01:02:03.004"},
 	}
 
 	for i, tt := range tests {
@@ -31,3 +31,23 @@ func TestDurationString(t *testing.T) {
 		})
 	}
 }
+
+func TestDurationSRTString(t *testing.T) {
+	tests := []struct {
+		name     string
+		d        Duration
+		expected string
+	}{
+		{"zero", Duration(0), "00:00:00,000"},
+		{"hours_minutes_seconds_millis", Duration(10*time.Hour + 9*time.Minute + 8*time.Second + 765*time.Millisecond), "10:09:08,765"},
+		{"negative_duration", Duration(-1500 * time.Millisecond), "-00:00:01,500"},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.SRTString(); got != tt.expected {
+				t.Fatalf("[%d] expected %q, got %q", i, tt.expected, got)
+			}
+		})
+	}
+}
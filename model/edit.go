@@ -0,0 +1,144 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrIndexOutOfRange is returned when an edit refers to a position that
+	// does not exist.
+	ErrIndexOutOfRange = errors.New("cue index out of range")
+	// ErrOverlap is returned by strict edits that would make a cue end after
+	// the next one starts.
+	ErrOverlap = errors.New("cues overlap")
+)
+
+// EditOptions configures the editing methods of Subtitles.
+type EditOptions struct {
+	// Strict rejects, with ErrOverlap, edits that would make a cue end after
+	// the next one starts. Only the cues brought next to each other by the
+	// edit are checked, so that existing overlaps do not prevent editing.
+	Strict bool
+}
+
+// InsertAt returns a new Subtitles with c inserted at position index, from 0
+// to the number of cues, and renumbered.
+func (s Subtitles) InsertAt(index int, c Cue, opts EditOptions) (Subtitles, error) {
+	if index < 0 || index > len(s.Items) {
+		return s, fmt.Errorf("insert at %d: %w", index, ErrIndexOutOfRange)
+	}
+
+	order := make([]int, 0, len(s.Items)+1)
+	for i := range s.Items {
+		if i == index {
+			order = append(order, -1)
+		}
+		order = append(order, i)
+	}
+	if index == len(s.Items) {
+		order = append(order, -1)
+	}
+	return s.edit(order, c, opts)
+}
+
+// InsertSorted returns a new Subtitles with c inserted after the cues
+// starting at or before c.Start, and renumbered. The cues of s must be
+// sorted by Start.
+func (s Subtitles) InsertSorted(c Cue, opts EditOptions) (Subtitles, error) {
+	index := sort.Search(len(s.Items), func(i int) bool {
+		return s.Items[i].Start > c.Start
+	})
+	return s.InsertAt(index, c, opts)
+}
+
+// ReplaceAt returns a new Subtitles with the cue at position index replaced
+// by c, and renumbered.
+func (s Subtitles) ReplaceAt(index int, c Cue, opts EditOptions) (Subtitles, error) {
+	if index < 0 || index >= len(s.Items) {
+		return s, fmt.Errorf("replace at %d: %w", index, ErrIndexOutOfRange)
+	}
+
+	order := make([]int, len(s.Items))
+	for i := range order {
+		order[i] = i
+	}
+	order[index] = -1
+	return s.edit(order, c, opts)
+}
+
+// Move returns a new Subtitles with the cue at position from moved to
+// position to, the cues in between shifting by one, and renumbered.
+func (s Subtitles) Move(from, to int, opts EditOptions) (Subtitles, error) {
+	if from < 0 || from >= len(s.Items) || to < 0 || to >= len(s.Items) {
+		return s, fmt.Errorf("move from %d to %d: %w", from, to, ErrIndexOutOfRange)
+	}
+
+	order := make([]int, 0, len(s.Items))
+	for i := range s.Items {
+		if i != from {
+			order = append(order, i)
+		}
+	}
+	order = append(order[:to], append([]int{from}, order[to:]...)...)
+	return s.edit(order, Cue{}, opts)
+}
+
+// Swap returns a new Subtitles with the cues at positions i and j swapped,
+// and renumbered.
+func (s Subtitles) Swap(i, j int, opts EditOptions) (Subtitles, error) {
+	if i < 0 || i >= len(s.Items) || j < 0 || j >= len(s.Items) {
+		return s, fmt.Errorf("swap %d and %d: %w", i, j, ErrIndexOutOfRange)
+	}
+
+	order := make([]int, len(s.Items))
+	for k := range order {
+		order[k] = k
+	}
+	order[i], order[j] = j, i
+	return s.edit(order, Cue{}, opts)
+}
+
+// edit returns a new Subtitles with the cues of s in the given order, -1
+// standing for c, and renumbered. In strict mode, the cues that were not
+// next to each other in s are checked for overlaps.
+func (s Subtitles) edit(order []int, c Cue, opts EditOptions) (Subtitles, error) {
+	items := make([]Cue, len(order))
+	for k, i := range order {
+		if i < 0 {
+			items[k] = c
+		} else {
+			items[k] = s.Items[i]
+		}
+	}
+
+	if opts.Strict {
+		for k := 0; k+1 < len(items); k++ {
+			adjacent := order[k] >= 0 && order[k+1] == order[k]+1
+			if !adjacent && items[k].End > items[k+1].Start {
+				return s, fmt.Errorf("cues %d and %d: %w", k+1, k+2, ErrOverlap)
+			}
+		}
+	}
+
+	renumber(items)
+	return Subtitles{Items: items}, nil
+}
+
+// FindAt returns the position of the cue displayed at t. The cues of s must
+// be sorted by Start. The last cue starting at or before t is found by binary
+// search; if it has already ended, earlier cues are scanned back for one still
+// displayed at t, which happens when a longer cue overlaps shorter ones. When
+// several cues are displayed at t, the one starting last is returned.
+func (s Subtitles) FindAt(t Duration) (int, bool) {
+	i := sort.Search(len(s.Items), func(i int) bool {
+		return s.Items[i].Start > t
+	}) - 1
+	for ; i >= 0; i-- {
+		if t < s.Items[i].End {
+			return i, true
+		}
+	}
+	return -1, false
+}
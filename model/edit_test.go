@@ -0,0 +1,233 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubtitles_InsertAt(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A"},
+		{Index: 2, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "B"},
+		{Index: 3, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "C"},
+	}}
+
+	result, err := subtitles.InsertAt(1, Cue{Start: Duration(2 * time.Second), End: Duration(3 * time.Second), Text: "new"}, EditOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A"},
+		{Index: 2, Start: Duration(2 * time.Second), End: Duration(3 * time.Second), Text: "new"},
+		{Index: 3, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "B"},
+		{Index: 4, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "C"},
+	}, result.Items)
+
+	result, err = subtitles.InsertAt(3, Cue{Start: Duration(7 * time.Second), End: Duration(8 * time.Second), Text: "new"}, EditOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Equal(t, Cue{Index: 4, Start: Duration(7 * time.Second), End: Duration(8 * time.Second), Text: "new"}, result.Items[3])
+
+	result, err = subtitles.InsertAt(0, Cue{Start: 0, End: Duration(1500 * time.Millisecond), Text: "new"}, EditOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, Cue{Index: 1, Start: 0, End: Duration(1500 * time.Millisecond), Text: "new"}, result.Items[0])
+	assert.Equal(t, Cue{Index: 2, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A"}, result.Items[1])
+
+	_, err = subtitles.InsertAt(0, Cue{Start: 0, End: Duration(1500 * time.Millisecond), Text: "new"}, EditOptions{Strict: true})
+	assert.True(t, errors.Is(err, ErrOverlap))
+	assert.EqualError(t, err, "cues 1 and 2: cues overlap")
+
+	_, err = subtitles.InsertAt(4, Cue{Start: Duration(7 * time.Second), End: Duration(8 * time.Second), Text: "new"}, EditOptions{})
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+
+	assert.Len(t, subtitles.Items, 3, "Expected original subtitles to be unchanged")
+	assert.Equal(t, "B", subtitles.Items[1].Text, "Expected original subtitles to be unchanged")
+}
+
+func TestSubtitles_InsertSorted(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A"},
+		{Index: 2, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "B"},
+		{Index: 3, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "C"},
+	}}
+
+	result, err := subtitles.InsertSorted(Cue{Start: Duration(4 * time.Second), End: Duration(5 * time.Second), Text: "new"}, EditOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A"},
+		{Index: 2, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "B"},
+		{Index: 3, Start: Duration(4 * time.Second), End: Duration(5 * time.Second), Text: "new"},
+		{Index: 4, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "C"},
+	}, result.Items)
+
+	result, err = subtitles.InsertSorted(Cue{Start: Duration(3 * time.Second), End: Duration(3500 * time.Millisecond), Text: "same start"}, EditOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, Cue{Index: 3, Start: Duration(3 * time.Second), End: Duration(3500 * time.Millisecond), Text: "same start"}, result.Items[2])
+
+	result, err = subtitles.InsertSorted(Cue{Start: 0, End: Duration(500 * time.Millisecond), Text: "first"}, EditOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Equal(t, Cue{Index: 1, Start: 0, End: Duration(500 * time.Millisecond), Text: "first"}, result.Items[0])
+	assert.Equal(t, 4, result.Items[3].Index)
+
+	_, err = subtitles.InsertSorted(Cue{Start: Duration(3500 * time.Millisecond), End: Duration(5500 * time.Millisecond), Text: "new"}, EditOptions{Strict: true})
+	assert.True(t, errors.Is(err, ErrOverlap))
+
+	result, err = Subtitles{}.InsertSorted(Cue{Start: 0, End: Duration(1 * time.Second), Text: "only"}, EditOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []Cue{{Index: 1, Start: 0, End: Duration(1 * time.Second), Text: "only"}}, result.Items)
+}
+
+func TestSubtitles_ReplaceAt(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A"},
+		{Index: 2, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "B"},
+		{Index: 3, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "C"},
+	}}
+
+	result, err := subtitles.ReplaceAt(1, Cue{Start: Duration(2500 * time.Millisecond), End: Duration(4500 * time.Millisecond), Text: "new"}, EditOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A"},
+		{Index: 2, Start: Duration(2500 * time.Millisecond), End: Duration(4500 * time.Millisecond), Text: "new"},
+		{Index: 3, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "C"},
+	}, result.Items)
+
+	_, err = subtitles.ReplaceAt(1, Cue{Start: Duration(1500 * time.Millisecond), End: Duration(4 * time.Second), Text: "new"}, EditOptions{Strict: true})
+	assert.True(t, errors.Is(err, ErrOverlap))
+
+	_, err = subtitles.ReplaceAt(3, Cue{Start: Duration(7 * time.Second), End: Duration(8 * time.Second), Text: "new"}, EditOptions{})
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+
+	assert.Equal(t, "B", subtitles.Items[1].Text, "Expected original subtitles to be unchanged")
+}
+
+func TestSubtitles_Move(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A"},
+		{Index: 2, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "B"},
+		{Index: 3, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "C"},
+	}}
+
+	result, err := subtitles.Move(0, 2, EditOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "B"},
+		{Index: 2, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "C"},
+		{Index: 3, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A"},
+	}, result.Items)
+
+	result, err = subtitles.Move(2, 0, EditOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "C"},
+		{Index: 2, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A"},
+		{Index: 3, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "B"},
+	}, result.Items)
+
+	result, err = subtitles.Move(1, 1, EditOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Equal(t, subtitles.Items, result.Items)
+
+	_, err = subtitles.Move(0, 2, EditOptions{Strict: true})
+	assert.True(t, errors.Is(err, ErrOverlap))
+	assert.EqualError(t, err, "cues 2 and 3: cues overlap")
+
+	_, err = subtitles.Move(0, 3, EditOptions{})
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+
+	assert.Equal(t, "A", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+}
+
+func TestSubtitles_Swap(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A"},
+		{Index: 2, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "B"},
+		{Index: 3, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "C"},
+	}}
+
+	result, err := subtitles.Swap(0, 2, EditOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "C"},
+		{Index: 2, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "B"},
+		{Index: 3, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A"},
+	}, result.Items)
+
+	_, err = subtitles.Swap(0, 1, EditOptions{Strict: true})
+	assert.True(t, errors.Is(err, ErrOverlap))
+
+	_, err = subtitles.Swap(-1, 1, EditOptions{})
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+
+	assert.Equal(t, "A", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+}
+
+func TestSubtitles_StrictIgnoresExistingOverlaps(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(3500 * time.Millisecond), Text: "A"},
+		{Index: 2, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "B"},
+		{Index: 3, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "C"},
+	}}
+
+	result, err := subtitles.InsertAt(3, Cue{Start: Duration(7 * time.Second), End: Duration(8 * time.Second), Text: "new"}, EditOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 4)
+}
+
+func TestSubtitles_FindAt(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A"},
+		{Index: 2, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "B"},
+		{Index: 3, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "C"},
+	}}
+
+	tests := []struct {
+		at       time.Duration
+		expected int
+		found    bool
+	}{
+		{500 * time.Millisecond, -1, false},
+		{1 * time.Second, 0, true},
+		{1999 * time.Millisecond, 0, true},
+		{2 * time.Second, -1, false},
+		{3500 * time.Millisecond, 1, true},
+		{5 * time.Second, 2, true},
+		{6 * time.Second, -1, false},
+		{10 * time.Second, -1, false},
+	}
+
+	for _, tt := range tests {
+		i, found := subtitles.FindAt(Duration(tt.at))
+		assert.Equal(t, tt.expected, i, "at %v", tt.at)
+		assert.Equal(t, tt.found, found, "at %v", tt.at)
+	}
+
+	_, found := Subtitles{}.FindAt(0)
+	assert.False(t, found)
+}
+
+func TestSubtitles_FindAtOverlap(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(0), End: Duration(10 * time.Second), Text: "A"},
+		{Index: 2, Start: Duration(2 * time.Second), End: Duration(3 * time.Second), Text: "B"},
+		{Index: 3, Start: Duration(4 * time.Second), End: Duration(5 * time.Second), Text: "C"},
+	}}
+
+	tests := []struct {
+		at       time.Duration
+		expected int
+		found    bool
+	}{
+		{1 * time.Second, 0, true},
+		{2500 * time.Millisecond, 1, true},
+		{3500 * time.Millisecond, 0, true},
+		{5 * time.Second, 0, true},
+		{10 * time.Second, -1, false},
+	}
+
+	for _, tt := range tests {
+		i, found := subtitles.FindAt(Duration(tt.at))
+		assert.Equal(t, tt.expected, i, "at %v", tt.at)
+		assert.Equal(t, tt.found, found, "at %v", tt.at)
+	}
+}
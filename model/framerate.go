@@ -0,0 +1,98 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// ntscRates are the nominal integer rates whose NTSC variants run 1000/1001
+// times slower (23.976, 29.97, 47.952, 59.94 and 119.88 fps).
+var ntscRates = []int64{24, 30, 48, 60, 120}
+
+// frameRate returns the exact rational value num/den of a frame rate. NTSC
+// rates given as rounded decimals, such as 23.976 or 29.97, are recognized
+// and mapped to their exact 1000/1001 ratio.
+func frameRate(fps float64) (num, den int64) {
+	for _, rate := range ntscRates {
+		ntsc := float64(rate*1000) / 1001
+		if math.Abs(fps-ntsc) < 0.005 {
+			return rate * 1000, 1001
+		}
+	}
+	return int64(math.Round(fps * 1000)), 1000
+}
+
+// scale returns d multiplied by num/den, rounded to the nearest millisecond.
+func (d Duration) scale(num, den int64) Duration {
+	ms := time.Duration(d).Milliseconds()
+	if rem := time.Duration(d) - time.Duration(ms)*time.Millisecond; rem >= time.Millisecond/2 {
+		ms++
+	} else if rem <= -time.Millisecond/2 {
+		ms--
+	}
+
+	scaled := ms * num
+	half := den / 2
+	if scaled < 0 {
+		half = -half
+	}
+	return Duration(time.Duration((scaled+half)/den) * time.Millisecond)
+}
+
+// frameRateRatio returns the ratio from/to as an irreducible fraction, or
+// ok=false if either rate is not positive.
+func frameRateRatio(from, to float64) (num, den int64, ok bool) {
+	if !(from > 0) || !(to > 0) {
+		return 0, 0, false
+	}
+
+	fromNum, fromDen := frameRate(from)
+	toNum, toDen := frameRate(to)
+	if fromNum <= 0 || toNum <= 0 {
+		return 0, 0, false
+	}
+
+	num, den = fromNum*toDen, fromDen*toNum
+	g := gcd(num, den)
+	return num / g, den / g, true
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// ConvertFrameRate returns a new Cue retimed from a video at the from frame
+// rate to a video at the to frame rate, so that it stays on the same frames.
+// Times are scaled by the exact ratio from/to and rounded to the millisecond.
+// The Cue is returned unchanged if either rate is not positive.
+func (c Cue) ConvertFrameRate(from, to float64) Cue {
+	num, den, ok := frameRateRatio(from, to)
+	if !ok {
+		return c
+	}
+	return c.convertFrameRate(num, den)
+}
+
+func (c Cue) convertFrameRate(num, den int64) Cue {
+	c.Start = c.Start.scale(num, den)
+	c.End = c.End.scale(num, den)
+	return c
+}
+
+// ConvertFrameRate returns a new Subtitles with all Cue times converted from
+// the from frame rate to the to frame rate. See Cue.ConvertFrameRate.
+func (s Subtitles) ConvertFrameRate(from, to float64) Subtitles {
+	num, den, ok := frameRateRatio(from, to)
+	if !ok {
+		return Subtitles{Items: s.clone()}
+	}
+
+	converted := make([]Cue, len(s.Items))
+	for i, cue := range s.Items {
+		converted[i] = cue.convertFrameRate(num, den)
+	}
+	return Subtitles{Items: converted}
+}
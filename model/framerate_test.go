@@ -0,0 +1,95 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrameRate(t *testing.T) {
+	tests := []struct {
+		fps      float64
+		num, den int64
+	}{
+		{23.976, 24000, 1001},
+		{23.98, 24000, 1001},
+		{24000.0 / 1001, 24000, 1001},
+		{29.97, 30000, 1001},
+		{59.94, 60000, 1001},
+		{24, 24000, 1000},
+		{25, 25000, 1000},
+		{30, 30000, 1000},
+	}
+
+	for _, tt := range tests {
+		num, den := frameRate(tt.fps)
+		assert.Equal(t, tt.num, num, "numerator for %v", tt.fps)
+		assert.Equal(t, tt.den, den, "denominator for %v", tt.fps)
+	}
+}
+
+func TestCue_ConvertFrameRate(t *testing.T) {
+	cue := Cue{
+		Index: 1,
+		Start: Duration(1 * time.Hour),
+		End:   Duration(1*time.Hour + 2*time.Second),
+		Text:  "Hello",
+	}
+
+	// 23.976 -> 25: times shrink by 24000/25025.
+	converted := cue.ConvertFrameRate(23.976, 25)
+	assert.Equal(t, "00:57:32,547", converted.Start.SRTString())
+	assert.Equal(t, "00:57:34,466", converted.End.SRTString())
+	assert.Equal(t, "Hello", converted.Text)
+	assert.Equal(t, 1, converted.Index)
+
+	// 25 -> 23.976: times grow by 25025/24000.
+	converted = cue.ConvertFrameRate(25, 23.976)
+	assert.Equal(t, "01:02:33,750", converted.Start.SRTString())
+	assert.Equal(t, "01:02:35,835", converted.End.SRTString())
+
+	assert.Equal(t, cue, cue.ConvertFrameRate(25, 25))
+	assert.Equal(t, cue, cue.ConvertFrameRate(0, 25))
+	assert.Equal(t, cue, cue.ConvertFrameRate(25, -1))
+}
+
+func TestSubtitles_ConvertFrameRate(t *testing.T) {
+	subtitles := Subtitles{
+		Items: []Cue{
+			{Index: 1, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "First"},
+			{Index: 2, Start: Duration(25 * time.Second), End: Duration(30 * time.Second), Text: "Second"},
+		},
+	}
+
+	converted := subtitles.ConvertFrameRate(25, 24)
+
+	assert.Equal(t, Duration(1*time.Second+42*time.Millisecond), converted.Items[0].Start)
+	assert.Equal(t, Duration(3*time.Second+125*time.Millisecond), converted.Items[0].End)
+	assert.Equal(t, Duration(26*time.Second+42*time.Millisecond), converted.Items[1].Start)
+	assert.Equal(t, Duration(31*time.Second+250*time.Millisecond), converted.Items[1].End)
+	assert.Equal(t, Duration(1*time.Second), subtitles.Items[0].Start, "Expected original subtitles to be unchanged")
+}
+
+func TestSubtitles_ConvertFrameRateStable(t *testing.T) {
+	var items []Cue
+	for ms := 0; ms < 2*60*60*1000; ms += 997 {
+		items = append(items, Cue{Start: Duration(time.Duration(ms) * time.Millisecond), End: Duration(time.Duration(ms+1500) * time.Millisecond)})
+	}
+	original := Subtitles{Items: items}
+
+	converted := original
+	for i := 0; i < 10; i++ {
+		converted = converted.ConvertFrameRate(25, 23.976).ConvertFrameRate(23.976, 25)
+	}
+
+	for i, cue := range converted.Items {
+		diff := time.Duration(cue.Start - original.Items[i].Start)
+		if diff < -time.Millisecond || diff > time.Millisecond {
+			t.Fatalf("[%d] start drifted by %v after repeated conversions", i, diff)
+		}
+		if time.Duration(cue.Start)%time.Millisecond != 0 {
+			t.Fatalf("[%d] start %v is not a whole millisecond", i, time.Duration(cue.Start))
+		}
+	}
+}
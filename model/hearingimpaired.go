@@ -0,0 +1,311 @@
+package model
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Brackets are the delimiters enclosing an annotation, such as "[" and "]".
+type Brackets struct {
+	Open  string
+	Close string
+}
+
+// DefaultBrackets are the brackets used by RemoveHearingImpaired when none
+// are supplied.
+var DefaultBrackets = []Brackets{
+	{Open: "[", Close: "]"},
+	{Open: "(", Close: ")"},
+	{Open: "［", Close: "］"},
+	{Open: "（", Close: "）"},
+}
+
+// DefaultSpeakerLabels are the speaker label patterns used by
+// RemoveHearingImpaired when none are supplied. They match names in capital
+// letters followed by a colon, such as "JOHN:" or "DR. SMITH:".
+var DefaultSpeakerLabels = []*regexp.Regexp{speakerLabel}
+
+// MusicPolicy is how RemoveHearingImpaired handles lines with music notes.
+type MusicPolicy int
+
+const (
+	// MusicRemoveLines removes the lines containing a music note.
+	MusicRemoveLines MusicPolicy = iota
+	// MusicRemoveNotes removes the music notes and keeps the lyrics.
+	MusicRemoveNotes
+	// MusicKeep keeps the lines with music notes as they are.
+	MusicKeep
+)
+
+// HearingImpairedOptions configures RemoveHearingImpaired.
+type HearingImpairedOptions struct {
+	// Brackets are the delimiters of the annotations to remove, which may
+	// span several lines. Nil means DefaultBrackets.
+	Brackets []Brackets
+	// SpeakerLabels match the speaker labels to remove at the start of a
+	// line, after an optional dialogue dash. Nil means DefaultSpeakerLabels.
+	SpeakerLabels []*regexp.Regexp
+	Music         MusicPolicy
+	// RemoveCapsLines removes the lines written in capital letters only, as
+	// sound descriptions, when they have at least four letters and do not
+	// end like a sentence. It is off by default, as shouted dialogue such as
+	// "GET OUT" is written the same way.
+	RemoveCapsLines bool
+}
+
+// HearingImpairedRemoval is what RemoveHearingImpaired removed from a cue.
+type HearingImpairedRemoval struct {
+	// Cue is the position of the cue in the original Subtitles.
+	Cue int
+	// Removed are the removed annotations, in order.
+	Removed []string
+	// Dropped reports whether the cue was removed for being left empty.
+	Dropped bool
+}
+
+// RemoveHearingImpaired returns a new Subtitles without the annotations for
+// the deaf and hard of hearing: bracketed sound descriptions, music, speaker
+// labels and, optionally, lines in capital letters, as configured by opts. Formatting is
+// kept around the remaining text. Cues left empty are removed and the result
+// is renumbered. When a dialogue is left with a single speaker, the dash of
+// the remaining line is removed. The report lists what was removed from each
+// changed cue.
+func (s Subtitles) RemoveHearingImpaired(opts HearingImpairedOptions) (Subtitles, []HearingImpairedRemoval) {
+	items := make([]Cue, len(s.Items))
+	var report []HearingImpairedRemoval
+	var empty []int
+	for i, cue := range s.Items {
+		var removed []string
+		items[i], removed = cue.removeHearingImpaired(opts)
+		if len(removed) == 0 {
+			continue
+		}
+
+		r := HearingImpairedRemoval{Cue: i, Removed: removed}
+		if strings.TrimSpace(items[i].PlainText()) == "" {
+			r.Dropped = true
+			empty = append(empty, i)
+		}
+		report = append(report, r)
+	}
+
+	return Subtitles{Items: items}.RemoveAtIndices(empty), report
+}
+
+// removeHearingImpaired returns the Cue cleaned by RemoveHearingImpaired,
+// along with the removed annotations.
+func (c Cue) removeHearingImpaired(opts HearingImpairedOptions) (Cue, []string) {
+	rich := ParseRichText(c.Text)
+	plain := rich.PlainText()
+
+	found := annotations(plain, opts)
+	if len(found) == 0 {
+		return c, nil
+	}
+
+	var removed []string
+	end := 0
+	for _, r := range found {
+		// Skip annotations within a larger one, such as brackets in a line
+		// of capital letters.
+		if r.end <= end {
+			continue
+		}
+		if text := strings.TrimSpace(plain[r.start:r.end]); text != "" {
+			removed = append(removed, text)
+		}
+		if r.end > end {
+			end = r.end
+		}
+	}
+
+	rich = rich.without(mergeRanges(found))
+	rich = rich.without(untidyRanges(rich.PlainText()))
+	rich = rich.slice(0, len(rich.PlainText()))
+
+	if dashes(plain) > 1 {
+		rest := rich.PlainText()
+		if dashes(rest) == 1 {
+			for _, line := range lines(rest) {
+				if n := len(dialogueDash.FindString(rest[line.start:line.end])); n > 0 {
+					rich = rich.without([]textRange{{line.start, line.start + n}})
+					break
+				}
+			}
+		}
+	}
+
+	c.Text = rich.String()
+	return c, removed
+}
+
+// annotations returns the ranges of plain text to remove, sorted by start,
+// the longest first.
+func annotations(plain string, opts HearingImpairedOptions) []textRange {
+	var ranges []textRange
+
+	brackets := opts.Brackets
+	if brackets == nil {
+		brackets = DefaultBrackets
+	}
+	for _, b := range brackets {
+		if b.Open == "" || b.Close == "" {
+			continue
+		}
+		for from := 0; ; {
+			i := strings.Index(plain[from:], b.Open)
+			if i < 0 {
+				break
+			}
+			start := from + i
+			j := strings.Index(plain[start+len(b.Open):], b.Close)
+			if j < 0 {
+				break
+			}
+			from = start + len(b.Open) + j + len(b.Close)
+			ranges = append(ranges, textRange{start, from})
+		}
+	}
+
+	labels := opts.SpeakerLabels
+	if labels == nil {
+		labels = DefaultSpeakerLabels
+	}
+	for _, line := range lines(plain) {
+		text := plain[line.start:line.end]
+		if strings.ContainsAny(text, "♪♫") {
+			switch opts.Music {
+			case MusicRemoveLines:
+				ranges = append(ranges, line)
+				continue
+			case MusicRemoveNotes:
+				for i, r := range text {
+					if r == '♪' || r == '♫' {
+						ranges = append(ranges, textRange{line.start + i, line.start + i + len(string(r))})
+					}
+				}
+			}
+		}
+
+		content := line.start + len(dialogueDash.FindString(text))
+		for _, label := range labels {
+			if loc := label.FindStringIndex(plain[content:line.end]); loc != nil && loc[0] == 0 && loc[1] > 0 {
+				ranges = append(ranges, textRange{content, content + loc[1]})
+				content += loc[1]
+				break
+			}
+		}
+
+		if opts.RemoveCapsLines && isCapsLine(plain[content:line.end]) {
+			ranges = append(ranges, textRange{content, line.end})
+		}
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].start != ranges[j].start {
+			return ranges[i].start < ranges[j].start
+		}
+		return ranges[i].end > ranges[j].end
+	})
+	return ranges
+}
+
+// isCapsLine reports whether text is written in capital letters only, has
+// at least four letters and does not end like a sentence.
+func isCapsLine(text string) bool {
+	text = strings.TrimSpace(text)
+	letters := 0
+	for _, r := range text {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters >= 4 && !strings.ContainsRune(".!?…", lastRune(text))
+}
+
+// untidyRanges returns the ranges of plain text to remove once annotations
+// are gone: lines left empty or with a lone dash, white space around lines
+// and repeated white space.
+func untidyRanges(plain string) []textRange {
+	var ranges []textRange
+	for _, line := range lines(plain) {
+		text := plain[line.start:line.end]
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || trimmed == "-" || trimmed == "–" {
+			// Drop the line along with the line break that ends it, or that
+			// precedes it for the last line.
+			switch {
+			case line.end < len(plain):
+				line.end++
+			case line.start > 0:
+				line.start--
+			}
+			ranges = append(ranges, line)
+			continue
+		}
+
+		first := line.start + strings.Index(text, trimmed)
+		last := first + len(trimmed)
+		ranges = append(ranges, textRange{line.start, first}, textRange{last, line.end})
+
+		space := false
+		for i, r := range trimmed {
+			if unicode.IsSpace(r) && space {
+				ranges = append(ranges, textRange{first + i, first + i + len(string(r))})
+			}
+			space = unicode.IsSpace(r)
+		}
+	}
+	return mergeRanges(ranges)
+}
+
+// lines returns the ranges of the lines of plain text, without line breaks.
+func lines(plain string) []textRange {
+	var ranges []textRange
+	start := 0
+	for i := 0; i <= len(plain); i++ {
+		if i == len(plain) || plain[i] == '\n' {
+			ranges = append(ranges, textRange{start, i})
+			start = i + 1
+		}
+	}
+	return ranges
+}
+
+// dashes returns the number of lines of plain text starting with a dash.
+func dashes(plain string) int {
+	n := 0
+	for _, line := range lines(plain) {
+		if startsDialogue(plain[line.start:line.end]) {
+			n++
+		}
+	}
+	return n
+}
+
+// mergeRanges returns ranges sorted by start, with overlapping and adjacent
+// ranges merged and empty ones dropped.
+func mergeRanges(ranges []textRange) []textRange {
+	sorted := append([]textRange(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].start < sorted[j].start })
+
+	var merged []textRange
+	for _, r := range sorted {
+		if r.start >= r.end {
+			continue
+		}
+		if n := len(merged); n > 0 && r.start <= merged[n-1].end {
+			if r.end > merged[n-1].end {
+				merged[n-1].end = r.end
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
@@ -0,0 +1,152 @@
+package model
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCue_RemoveHearingImpaired(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		opts     HearingImpairedOptions
+		expected string
+		removed  []string
+	}{
+		{
+			name:     "nothing to remove",
+			text:     "<i>Hello there.</i>",
+			expected: "<i>Hello there.</i>",
+		},
+		{
+			name:     "square brackets",
+			text:     "[DOOR SLAMS] Who's there?",
+			expected: "Who's there?",
+			removed:  []string{"[DOOR SLAMS]"},
+		},
+		{
+			name:     "parentheses within a line",
+			text:     "I told you (laughs) it works.",
+			expected: "I told you it works.",
+			removed:  []string{"(laughs)"},
+		},
+		{
+			name:     "brackets across lines",
+			text:     "[thunder\nrumbling]\nRun!",
+			expected: "Run!",
+			removed:  []string{"[thunder\nrumbling]"},
+		},
+		{
+			name:     "speaker label",
+			text:     "JOHN: Come here.",
+			expected: "Come here.",
+			removed:  []string{"JOHN:"},
+		},
+		{
+			name:     "dialogue labels",
+			text:     "- JOHN: Hi.\n- MARY: Hey.",
+			expected: "- Hi.\n- Hey.",
+			removed:  []string{"JOHN:", "MARY:"},
+		},
+		{
+			name:     "single speaker remnant",
+			text:     "- [DOOR SLAMS]\n- Who's there?",
+			expected: "Who's there?",
+			removed:  []string{"[DOOR SLAMS]"},
+		},
+		{
+			name:     "caps line kept by default",
+			text:     "GET OUT\nNow.",
+			expected: "GET OUT\nNow.",
+		},
+		{
+			name:     "caps line",
+			text:     "PHONE RINGING\nAnswer it.",
+			opts:     HearingImpairedOptions{RemoveCapsLines: true},
+			expected: "Answer it.",
+			removed:  []string{"PHONE RINGING"},
+		},
+		{
+			name:     "shouted line kept",
+			text:     "HELP ME!",
+			opts:     HearingImpairedOptions{RemoveCapsLines: true},
+			expected: "HELP ME!",
+		},
+		{
+			name:     "music line",
+			text:     "♪ Soft music ♪\nListen.",
+			expected: "Listen.",
+			removed:  []string{"♪ Soft music ♪"},
+		},
+		{
+			name:     "music notes",
+			text:     "♪ Oh, say can you see ♪",
+			opts:     HearingImpairedOptions{Music: MusicRemoveNotes},
+			expected: "Oh, say can you see",
+			removed:  []string{"♪", "♪"},
+		},
+		{
+			name:     "music kept",
+			text:     "♪ Oh, say can you see ♪",
+			opts:     HearingImpairedOptions{Music: MusicKeep},
+			expected: "♪ Oh, say can you see ♪",
+		},
+		{
+			name:     "custom brackets and labels",
+			text:     "John: *sighs* Fine.",
+			opts:     HearingImpairedOptions{Brackets: []Brackets{{Open: "*", Close: "*"}}, SpeakerLabels: []*regexp.Regexp{regexp.MustCompile(`^\p{Lu}\p{Ll}+:\s*`)}},
+			expected: "Fine.",
+			removed:  []string{"John:", "*sighs*"},
+		},
+		{
+			name:     "formatting kept",
+			text:     `{\an8}<i>(whispering) Over</i> here.`,
+			expected: `{\an8}<i>Over</i> here.`,
+			removed:  []string{"(whispering)"},
+		},
+		{
+			name:     "left empty",
+			text:     "<i>[SIGHS]</i>",
+			expected: "",
+			removed:  []string{"[SIGHS]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cue := Cue{Index: 1, Start: 0, End: Duration(time.Second), Text: tt.text}
+			result, removed := cue.removeHearingImpaired(tt.opts)
+			assert.Equal(t, tt.expected, result.Text)
+			assert.Equal(t, tt.removed, removed)
+		})
+	}
+}
+
+func TestSubtitles_RemoveHearingImpaired(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: 0, End: Duration(time.Second), Text: "Hello."},
+		{Index: 2, Start: Duration(time.Second), End: Duration(2 * time.Second), Text: "[DOOR SLAMS]"},
+		{Index: 3, Start: Duration(2 * time.Second), End: Duration(3 * time.Second), Text: "MARY: Who's there?"},
+		{Index: 4, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "NO WAY, JOSÉ"},
+	}}
+
+	result, report := subtitles.RemoveHearingImpaired(HearingImpairedOptions{})
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: 0, End: Duration(time.Second), Text: "Hello."},
+		{Index: 2, Start: Duration(2 * time.Second), End: Duration(3 * time.Second), Text: "Who's there?"},
+		{Index: 3, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "NO WAY, JOSÉ"},
+	}, result.Items)
+	assert.Equal(t, []HearingImpairedRemoval{
+		{Cue: 1, Removed: []string{"[DOOR SLAMS]"}, Dropped: true},
+		{Cue: 2, Removed: []string{"MARY:"}},
+	}, report)
+
+	result, report = subtitles.RemoveHearingImpaired(HearingImpairedOptions{RemoveCapsLines: true})
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, HearingImpairedRemoval{Cue: 3, Removed: []string{"NO WAY, JOSÉ"}, Dropped: true}, report[2])
+
+	assert.Equal(t, "[DOOR SLAMS]", subtitles.Items[1].Text, "Expected original subtitles to be unchanged")
+}
@@ -0,0 +1,209 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// sentenceEnd matches the end of a sentence, along with closing quotes and
+// brackets, followed by white space or after a full-width full stop.
+var sentenceEnd = regexp.MustCompile(`[.!?…]+["'”’»)\]]*\s+|[。！？]+`)
+
+// clauseEnd matches the end of a clause followed by white space.
+var clauseEnd = regexp.MustCompile(`[,;:]\s+|[、，；：]+`)
+
+// speakerLabel matches a speaker name in capital letters written before
+// the text, such as "JOHN:", "DR. SMITH:" or "TOM & JERRY:", along with the
+// white space following it.
+var speakerLabel = regexp.MustCompile(`^(\p{Lu}[\p{Lu}\d .'&-]*):(?:\s+|$)`)
+
+// dialogueDash matches a dialogue dash at the start of a line, along with
+// the white space around it.
+var dialogueDash = regexp.MustCompile(`^\s*[-\x{2013}]\s*`)
+
+// SplitLongCues returns a new Subtitles in which the cues lasting more than
+// maxDuration or showing more than maxChars visible characters are split at
+// sentence boundaries, or at clause boundaries for a single sentence. Each
+// part is displayed for a time proportional to its length, and keeps its
+// formatting. Cues are split in halves as even as possible until every part
+// fits or no boundary is left. The result is renumbered. A non-positive
+// limit is not enforced.
+func (s Subtitles) SplitLongCues(maxDuration time.Duration, maxChars int) Subtitles {
+	var items []Cue
+	for _, cue := range s.Items {
+		items = append(items, cue.splitLong(maxDuration, maxChars)...)
+	}
+	renumber(items)
+
+	return Subtitles{Items: items}
+}
+
+// textRange is the part of a plain text between two byte offsets.
+type textRange struct {
+	start, end int
+}
+
+// splitLong returns the parts of the Cue split by SplitLongCues.
+func (c Cue) splitLong(maxDuration time.Duration, maxChars int) []Cue {
+	rich := ParseRichText(c.Text)
+	plain := rich.PlainText()
+	total := visibleCount(plain)
+	duration := time.Duration(c.End - c.Start)
+	if total == 0 {
+		return []Cue{c}
+	}
+
+	// fits reports whether the part of the text in r is short enough, given
+	// the share of the cue duration it gets.
+	fits := func(r textRange) bool {
+		n := visibleCount(plain[r.start:r.end])
+		if maxChars > 0 && n > maxChars {
+			return false
+		}
+		return maxDuration <= 0 || share(duration, n, total) <= maxDuration
+	}
+
+	var ranges []textRange
+	var split func(r textRange)
+	split = func(r textRange) {
+		if fits(r) {
+			ranges = append(ranges, r)
+			return
+		}
+		at, ok := middleBoundary(plain, r, sentenceEnd)
+		if !ok {
+			at, ok = middleBoundary(plain, r, clauseEnd)
+		}
+		if !ok {
+			ranges = append(ranges, r)
+			return
+		}
+		split(textRange{r.start, at})
+		split(textRange{at, r.end})
+	}
+	split(textRange{0, len(plain)})
+
+	if len(ranges) == 1 {
+		return []Cue{c}
+	}
+
+	// Time is shared between the parts as trimmed, which have fewer
+	// characters than the whole text.
+	counts := make([]int, len(ranges))
+	total = 0
+	for i, r := range ranges {
+		counts[i] = visibleCount(plain[r.start:r.end])
+		total += counts[i]
+	}
+
+	parts := make([]Cue, len(ranges))
+	before := 0
+	for i, r := range ranges {
+		part := c
+		part.Text = rich.slice(r.start, r.end).String()
+		part.Start = c.Start.Add(share(duration, before, total))
+		before += counts[i]
+		part.End = c.Start.Add(share(duration, before, total))
+		parts[i] = part
+	}
+	parts[len(parts)-1].End = c.End
+
+	return parts
+}
+
+// share returns the part of duration for n characters out of total, rounded
+// to the millisecond.
+func share(duration time.Duration, n, total int) time.Duration {
+	return (duration * time.Duration(n) / time.Duration(total)).Round(time.Millisecond)
+}
+
+// middleBoundary returns the offset of the boundary matched by pattern in
+// the part r of plain text that is the closest to the middle of its visible
+// characters. Boundaries leaving an empty part are ignored.
+func middleBoundary(plain string, r textRange, pattern *regexp.Regexp) (int, bool) {
+	text := plain[r.start:r.end]
+	total := visibleCount(text)
+
+	best, bestDistance := 0, -1
+	for _, m := range pattern.FindAllStringIndex(text, -1) {
+		at := m[1]
+		before := visibleCount(text[:at])
+		if before == 0 || before == total {
+			continue
+		}
+		distance := before*2 - total
+		if distance < 0 {
+			distance = -distance
+		}
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = r.start+at, distance
+		}
+	}
+	return best, bestDistance >= 0
+}
+
+// visibleCount returns the number of characters of plain text, line breaks
+// excluded, as counted by Cue.VisibleCharCount.
+func visibleCount(plain string) int {
+	return utf8.RuneCountInString(strings.TrimSpace(strings.ReplaceAll(plain, "\n", "")))
+}
+
+// JoinShortCues returns a new Subtitles in which runs of consecutive cues
+// lasting less than minDuration, separated by at most maxGap and spoken by
+// the same speaker, are joined into a single cue showing their texts on
+// separate lines. A cue starting with a dialogue dash, or with a speaker
+// label other than the one of the previous cue, such as "JOHN:", is never
+// joined with it; repeated labels are removed. The result is renumbered.
+func (s Subtitles) JoinShortCues(minDuration, maxGap time.Duration) Subtitles {
+	var items []Cue
+	prevShort := false
+	for _, cue := range s.Items {
+		short := time.Duration(cue.End-cue.Start) < minDuration
+
+		if n := len(items); n > 0 && short && prevShort {
+			last := &items[n-1]
+			gap := time.Duration(cue.Start - last.End)
+			if text, ok := joinSpeech(last.Text, cue.Text); ok && gap >= 0 && gap <= maxGap {
+				last.Text = text
+				last.End = cue.End
+				continue
+			}
+		}
+
+		items = append(items, cue)
+		prevShort = short
+	}
+	renumber(items)
+
+	return Subtitles{Items: items}
+}
+
+// joinSpeech returns the text b appended to the text a on a new line, if b
+// is spoken by the same speaker as the end of a.
+func joinSpeech(a, b string) (string, bool) {
+	richB := ParseRichText(b)
+	plainA, plainB := ParseRichText(a).PlainText(), richB.PlainText()
+	if startsDialogue(plainB) || isDialogue(plainA) || isDialogue(plainB) {
+		return "", false
+	}
+
+	labelB := speakerLabel.FindStringSubmatchIndex(plainB)
+	if labelB == nil {
+		return a + "\n" + b, true
+	}
+	labelA := speakerLabel.FindStringSubmatch(plainA)
+	if labelA == nil || labelA[1] != plainB[labelB[2]:labelB[3]] {
+		return "", false
+	}
+
+	// Remove the repeated label from the plain text, as it may be wrapped in
+	// or followed by tags.
+	return a + "\n" + richB.without([]textRange{{0, labelB[1]}}).String(), true
+}
+
+// startsDialogue reports whether plain text starts with a dialogue dash.
+func startsDialogue(plain string) bool {
+	return dialogueDash.MatchString(plain)
+}
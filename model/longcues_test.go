@@ -0,0 +1,128 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubtitles_SplitLongCues(t *testing.T) {
+	ms := func(n int) Duration { return Duration(time.Duration(n) * time.Millisecond) }
+
+	tests := []struct {
+		name        string
+		cue         Cue
+		maxDuration time.Duration
+		maxChars    int
+		expected    []Cue
+	}{
+		{
+			name:        "too long",
+			cue:         Cue{Index: 1, Start: ms(1000), End: ms(7000), Text: `{\an8}<i>First sentence here. Second one is here.</i>`},
+			maxDuration: 4 * time.Second,
+			expected: []Cue{
+				{Index: 1, Start: ms(1000), End: ms(4077), Text: `{\an8}<i>First sentence here.</i>`},
+				{Index: 2, Start: ms(4077), End: ms(7000), Text: `{\an8}<i>Second one is here.</i>`},
+			},
+		},
+		{
+			name:     "too many characters",
+			cue:      Cue{Index: 1, Start: 0, End: ms(2200), Text: "One. Two.\nThree. Four."},
+			maxChars: 10,
+			expected: []Cue{
+				{Index: 1, Start: 0, End: ms(990), Text: "One. Two."},
+				{Index: 2, Start: ms(990), End: ms(1650), Text: "Three."},
+				{Index: 3, Start: ms(1650), End: ms(2200), Text: "Four."},
+			},
+		},
+		{
+			name:     "clause boundary",
+			cue:      Cue{Index: 1, Start: 0, End: ms(1600), Text: "Well, I think so"},
+			maxChars: 10,
+			expected: []Cue{
+				{Index: 1, Start: 0, End: ms(533), Text: "Well,"},
+				{Index: 2, Start: ms(533), End: ms(1600), Text: "I think so"},
+			},
+		},
+		{
+			name:     "balanced tags",
+			cue:      Cue{Index: 1, Start: 0, End: ms(2400), Text: "<b>Hello there. How</b> are you?"},
+			maxChars: 12,
+			expected: []Cue{
+				{Index: 1, Start: 0, End: ms(1200), Text: "<b>Hello there.</b>"},
+				{Index: 2, Start: ms(1200), End: ms(2400), Text: "<b>How</b> are you?"},
+			},
+		},
+		{
+			name:     "no boundary",
+			cue:      Cue{Index: 1, Start: 0, End: ms(1000), Text: "Unbreakable long text"},
+			maxChars: 5,
+			expected: []Cue{{Index: 1, Start: 0, End: ms(1000), Text: "Unbreakable long text"}},
+		},
+		{
+			name:        "fits",
+			cue:         Cue{Index: 1, Start: 0, End: ms(1000), Text: "Short. Enough."},
+			maxDuration: 2 * time.Second,
+			maxChars:    20,
+			expected:    []Cue{{Index: 1, Start: 0, End: ms(1000), Text: "Short. Enough."}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subtitles := Subtitles{Items: []Cue{tt.cue}}
+			result := subtitles.SplitLongCues(tt.maxDuration, tt.maxChars)
+			assert.Equal(t, tt.expected, result.Items)
+		})
+	}
+}
+
+func TestSubtitles_SplitLongCues_Renumbers(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: 0, End: Duration(2 * time.Second), Text: "One. Two."},
+		{Index: 2, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "Three."},
+	}}
+
+	result := subtitles.SplitLongCues(0, 5)
+	assert.Len(t, result.Items, 3)
+	for i, cue := range result.Items {
+		assert.Equal(t, i+1, cue.Index)
+	}
+	assert.Equal(t, "Three.", result.Items[2].Text)
+	assert.Equal(t, "One. Two.", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+}
+
+func TestSubtitles_JoinShortCues(t *testing.T) {
+	ms := func(n int) Duration { return Duration(time.Duration(n) * time.Millisecond) }
+
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: ms(0), End: ms(500), Text: "Hi."},
+		{Index: 2, Start: ms(600), End: ms(1000), Text: "<i>There.</i>"},
+		{Index: 3, Start: ms(1100), End: ms(1500), Text: "- Yes?"},
+		{Index: 4, Start: ms(3000), End: ms(3500), Text: "Later."},
+		{Index: 5, Start: ms(3600), End: ms(6000), Text: "Long enough to stand."},
+		{Index: 6, Start: ms(6100), End: ms(6500), Text: "Short again."},
+		{Index: 7, Start: ms(7000), End: ms(7500), Text: "JOHN: Hey."},
+		{Index: 8, Start: ms(7600), End: ms(8000), Text: "<b>JOHN: You.</b>"},
+		{Index: 9, Start: ms(8100), End: ms(8500), Text: "MARY: No."},
+		{Index: 10, Start: ms(9000), End: ms(9500), Text: "<b>JOHN:</b> Hello"},
+		{Index: 11, Start: ms(9600), End: ms(9900), Text: "<b>JOHN:</b> there"},
+		{Index: 12, Start: ms(10000), End: ms(10500), Text: `JOHN: {\an8}up here`},
+	}}
+
+	result := subtitles.JoinShortCues(time.Second, 200*time.Millisecond)
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: ms(0), End: ms(1000), Text: "Hi.\n<i>There.</i>"},
+		{Index: 2, Start: ms(1100), End: ms(1500), Text: "- Yes?"},
+		{Index: 3, Start: ms(3000), End: ms(3500), Text: "Later."},
+		{Index: 4, Start: ms(3600), End: ms(6000), Text: "Long enough to stand."},
+		{Index: 5, Start: ms(6100), End: ms(6500), Text: "Short again."},
+		{Index: 6, Start: ms(7000), End: ms(8000), Text: "JOHN: Hey.\n<b>You.</b>"},
+		{Index: 7, Start: ms(8100), End: ms(8500), Text: "MARY: No."},
+		{Index: 8, Start: ms(9000), End: ms(10500), Text: "<b>JOHN:</b> Hello\nthere\n" + `{\an8}up here`},
+	}, result.Items)
+
+	assert.Equal(t, "Hi.", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+	assert.Empty(t, Subtitles{}.JoinShortCues(time.Second, time.Second).Items)
+}
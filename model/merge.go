@@ -0,0 +1,149 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// MergePolicy is how Merge handles cues of the two tracks that overlap.
+type MergePolicy int
+
+const (
+	// MergeKeepBoth keeps overlapping cues as they are, to be displayed
+	// together by players that support it.
+	MergeKeepBoth MergePolicy = iota
+	// MergeStack cuts overlapping cues at each other's start and end, so
+	// that each piece shows the text of the cues displayed at that time, the
+	// first track above the second one.
+	MergeStack
+	// MergeTop keeps overlapping cues and moves those of the second track
+	// to the top of the screen with a "{\an8}" tag, unless they are
+	// already positioned.
+	MergeTop
+)
+
+// MergeOptions configures Merge.
+type MergeOptions struct {
+	Overlap MergePolicy
+}
+
+// mergedCue is a cue of a merged track along with the track it comes from.
+type mergedCue struct {
+	cue    Cue
+	second bool
+}
+
+// Merge returns a new Subtitles with the cues of a and b interleaved by
+// Start and renumbered. Cues of a come first when they start at the same
+// time as cues of b. Overlaps between the two tracks are handled according
+// to opts.Overlap.
+func Merge(a, b Subtitles, opts MergeOptions) Subtitles {
+	merged := make([]mergedCue, 0, len(a.Items)+len(b.Items))
+	for _, cue := range a.Items {
+		merged = append(merged, mergedCue{cue: cue})
+	}
+	for _, cue := range b.Items {
+		if opts.Overlap == MergeTop && cue.Alignment() == AlignDefault && overlapsAny(cue, a.Items) {
+			cue.Text = `{\an8}` + cue.Text
+		}
+		merged = append(merged, mergedCue{cue: cue, second: true})
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].cue.Start < merged[j].cue.Start
+	})
+
+	var items []Cue
+	if opts.Overlap == MergeStack {
+		items = stack(merged)
+	} else {
+		items = make([]Cue, len(merged))
+		for i, m := range merged {
+			items[i] = m.cue
+		}
+	}
+	renumber(items)
+
+	return Subtitles{Items: items}
+}
+
+// overlapsAny reports whether c is displayed at the same time as any of
+// items.
+func overlapsAny(c Cue, items []Cue) bool {
+	for _, other := range items {
+		if c.Start < other.End && other.Start < c.End {
+			return true
+		}
+	}
+	return false
+}
+
+// stack combines the groups of overlapping cues of merged that contain cues
+// from both tracks. The timeline of each group is cut at every cue start and
+// end, and each piece in which cues are displayed becomes a cue with the text
+// of the first track followed by the text of the second one.
+func stack(merged []mergedCue) []Cue {
+	var items []Cue
+	for i := 0; i < len(merged); {
+		end := merged[i].cue.End
+		j := i + 1
+		for j < len(merged) && merged[j].cue.Start < end {
+			if merged[j].cue.End > end {
+				end = merged[j].cue.End
+			}
+			j++
+		}
+
+		group := merged[i:j]
+		i = j
+
+		firstTrack, secondTrack := false, false
+		var times []Duration
+		for _, m := range group {
+			firstTrack = firstTrack || !m.second
+			secondTrack = secondTrack || m.second
+			times = append(times, m.cue.Start, m.cue.End)
+		}
+		if !firstTrack || !secondTrack {
+			for _, m := range group {
+				items = append(items, m.cue)
+			}
+			continue
+		}
+
+		sort.Slice(times, func(a, b int) bool { return times[a] < times[b] })
+		for k := 1; k < len(times); k++ {
+			from, to := times[k-1], times[k]
+			if from == to {
+				continue
+			}
+
+			// As pieces are cut at every start and end, a cue is displayed
+			// during the whole piece or not at all.
+			var visible *Cue
+			var first, second []string
+			for n, m := range group {
+				if m.cue.Start > from || m.cue.End < to {
+					continue
+				}
+				if visible == nil {
+					visible = &group[n].cue
+				}
+				if m.second {
+					second = append(second, m.cue.Text)
+				} else {
+					first = append(first, m.cue.Text)
+				}
+			}
+			if visible == nil {
+				continue
+			}
+
+			cue := *visible
+			cue.Start, cue.End = from, to
+			cue.Text = strings.Join(append(first, second...), "\n")
+			items = append(items, cue)
+		}
+	}
+	return items
+}
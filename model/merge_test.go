@@ -0,0 +1,107 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	dialogue := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "Hello"},
+		{Index: 2, Start: Duration(5 * time.Second), End: Duration(7 * time.Second), Text: "How are you?"},
+	}}
+	signs := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(0), End: Duration(500 * time.Millisecond), Text: "PARIS"},
+		{Index: 2, Start: Duration(6 * time.Second), End: Duration(8 * time.Second), Text: "EXIT"},
+	}}
+
+	tests := []struct {
+		name     string
+		policy   MergePolicy
+		expected []Cue
+	}{
+		{
+			name:   "keep both",
+			policy: MergeKeepBoth,
+			expected: []Cue{
+				{Index: 1, Start: Duration(0), End: Duration(500 * time.Millisecond), Text: "PARIS"},
+				{Index: 2, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "Hello"},
+				{Index: 3, Start: Duration(5 * time.Second), End: Duration(7 * time.Second), Text: "How are you?"},
+				{Index: 4, Start: Duration(6 * time.Second), End: Duration(8 * time.Second), Text: "EXIT"},
+			},
+		},
+		{
+			name:   "stack",
+			policy: MergeStack,
+			expected: []Cue{
+				{Index: 1, Start: Duration(0), End: Duration(500 * time.Millisecond), Text: "PARIS"},
+				{Index: 2, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "Hello"},
+				{Index: 3, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "How are you?"},
+				{Index: 4, Start: Duration(6 * time.Second), End: Duration(7 * time.Second), Text: "How are you?\nEXIT"},
+				{Index: 5, Start: Duration(7 * time.Second), End: Duration(8 * time.Second), Text: "EXIT"},
+			},
+		},
+		{
+			name:   "top",
+			policy: MergeTop,
+			expected: []Cue{
+				{Index: 1, Start: Duration(0), End: Duration(500 * time.Millisecond), Text: "PARIS"},
+				{Index: 2, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "Hello"},
+				{Index: 3, Start: Duration(5 * time.Second), End: Duration(7 * time.Second), Text: "How are you?"},
+				{Index: 4, Start: Duration(6 * time.Second), End: Duration(8 * time.Second), Text: `{\an8}EXIT`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := Merge(dialogue, signs, MergeOptions{Overlap: tt.policy})
+			assert.Equal(t, tt.expected, merged.Items)
+		})
+	}
+
+	assert.Equal(t, "EXIT", signs.Items[1].Text, "Expected original subtitles to be unchanged")
+	assert.Equal(t, 2, dialogue.Items[1].Index, "Expected original subtitles to be unchanged")
+}
+
+func TestMerge_TiesKeepFirstTrackFirst(t *testing.T) {
+	a := Subtitles{Items: []Cue{{Index: 1, Start: Duration(time.Second), End: Duration(2 * time.Second), Text: "A"}}}
+	b := Subtitles{Items: []Cue{{Index: 1, Start: Duration(time.Second), End: Duration(2 * time.Second), Text: "B"}}}
+
+	merged := Merge(b, a, MergeOptions{})
+	assert.Equal(t, "B", merged.Items[0].Text)
+	assert.Equal(t, "A", merged.Items[1].Text)
+}
+
+func TestMerge_StackCutsChains(t *testing.T) {
+	a := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(0), End: Duration(2 * time.Second), Text: "A1"},
+		{Index: 2, Start: Duration(3 * time.Second), End: Duration(5 * time.Second), Text: "A2"},
+	}}
+	b := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(4 * time.Second), Text: "B1"},
+	}}
+
+	merged := Merge(a, b, MergeOptions{Overlap: MergeStack})
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(0), End: Duration(1 * time.Second), Text: "A1"},
+		{Index: 2, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "A1\nB1"},
+		{Index: 3, Start: Duration(2 * time.Second), End: Duration(3 * time.Second), Text: "B1"},
+		{Index: 4, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "A2\nB1"},
+		{Index: 5, Start: Duration(4 * time.Second), End: Duration(5 * time.Second), Text: "A2"},
+	}, merged.Items)
+}
+
+func TestMerge_TopKeepsExplicitPosition(t *testing.T) {
+	a := Subtitles{Items: []Cue{{Index: 1, Start: Duration(0), End: Duration(2 * time.Second), Text: "Dialogue"}}}
+	b := Subtitles{Items: []Cue{{Index: 1, Start: Duration(time.Second), End: Duration(2 * time.Second), Text: `{\an7}Sign`}}}
+
+	merged := Merge(a, b, MergeOptions{Overlap: MergeTop})
+	assert.Equal(t, `{\an7}Sign`, merged.Items[1].Text)
+}
+
+func TestMerge_Empty(t *testing.T) {
+	assert.Empty(t, Merge(Subtitles{}, Subtitles{}, MergeOptions{}).Items)
+}
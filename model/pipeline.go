@@ -0,0 +1,95 @@
+package model
+
+import "sort"
+
+// Map returns a new Subtitles with every Cue replaced by the result of f.
+func (s Subtitles) Map(f func(Cue) Cue) Subtitles {
+	items := make([]Cue, len(s.Items))
+	for i, cue := range s.Items {
+		items[i] = f(cue)
+	}
+	return Subtitles{Items: items}
+}
+
+// Filter returns a new Subtitles with the cues for which keep returns true.
+// The cues keep their Index; use Renumber to number them again.
+func (s Subtitles) Filter(keep func(Cue) bool) Subtitles {
+	var items []Cue
+	for _, cue := range s.Items {
+		if keep(cue) {
+			items = append(items, cue)
+		}
+	}
+	return Subtitles{Items: items}
+}
+
+// Sort returns a new Subtitles with the cues sorted by Start. Cues starting
+// at the same time keep their order.
+func (s Subtitles) Sort() Subtitles {
+	items := s.clone()
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Start < items[j].Start
+	})
+	return Subtitles{Items: items}
+}
+
+// Renumber returns a new Subtitles with the cues numbered from 1 in order.
+func (s Subtitles) Renumber() Subtitles {
+	items := s.clone()
+	renumber(items)
+	return Subtitles{Items: items}
+}
+
+// Transform is an operation returning a new Subtitles. Like the methods of
+// Subtitles, it must not modify the Subtitles it is given.
+type Transform func(Subtitles) Subtitles
+
+// Pipeline is a sequence of transforms applied in order. Adding a step
+// returns a new Pipeline, so that a Pipeline can be shared and extended in
+// different ways.
+type Pipeline struct {
+	steps []Transform
+}
+
+// NewPipeline returns a Pipeline applying the given steps in order.
+func NewPipeline(steps ...Transform) Pipeline {
+	return Pipeline{}.Then(steps...)
+}
+
+// Then returns a new Pipeline applying the given steps after those of p.
+func (p Pipeline) Then(steps ...Transform) Pipeline {
+	all := make([]Transform, 0, len(p.steps)+len(steps))
+	all = append(all, p.steps...)
+	all = append(all, steps...)
+	return Pipeline{steps: all}
+}
+
+// Map returns a new Pipeline ending with Subtitles.Map.
+func (p Pipeline) Map(f func(Cue) Cue) Pipeline {
+	return p.Then(func(s Subtitles) Subtitles { return s.Map(f) })
+}
+
+// Filter returns a new Pipeline ending with Subtitles.Filter.
+func (p Pipeline) Filter(keep func(Cue) bool) Pipeline {
+	return p.Then(func(s Subtitles) Subtitles { return s.Filter(keep) })
+}
+
+// Sort returns a new Pipeline ending with Subtitles.Sort.
+func (p Pipeline) Sort() Pipeline {
+	return p.Then(Subtitles.Sort)
+}
+
+// Renumber returns a new Pipeline ending with Subtitles.Renumber.
+func (p Pipeline) Renumber() Pipeline {
+	return p.Then(Subtitles.Renumber)
+}
+
+// Apply returns the result of the steps of p applied in order to s, which
+// is left unchanged. Without steps, Apply returns a copy of s.
+func (p Pipeline) Apply(s Subtitles) Subtitles {
+	result := Subtitles{Items: s.clone()}
+	for _, step := range p.steps {
+		result = step(result)
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubtitles_Map(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "third"},
+		{Index: 2, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "first"},
+		{Index: 3, Start: Duration(2 * time.Second), End: Duration(3 * time.Second), Text: ""},
+	}}
+	mapped := subtitles.Map(func(c Cue) Cue {
+		c.Text = strings.ToUpper(c.Text)
+		return c
+	})
+
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "THIRD"},
+		{Index: 2, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "FIRST"},
+		{Index: 3, Start: Duration(2 * time.Second), End: Duration(3 * time.Second), Text: ""},
+	}, mapped.Items)
+	assert.Equal(t, "third", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+}
+
+func TestSubtitles_Filter(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "third"},
+		{Index: 2, Start: Duration(2 * time.Second), End: Duration(3 * time.Second), Text: ""},
+		{Index: 3, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "second"},
+	}}
+	filtered := subtitles.Filter(func(c Cue) bool { return c.Text != "" })
+
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "third"},
+		{Index: 3, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "second"},
+	}, filtered.Items)
+
+	filtered.Items[0].Text = "changed"
+	assert.Equal(t, "third", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+	assert.Len(t, subtitles.Items, 3)
+}
+
+func TestSubtitles_Sort(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "third"},
+		{Index: 2, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "first"},
+		{Index: 3, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "second"},
+	}}
+	sorted := subtitles.Sort()
+
+	assert.Equal(t, []Cue{
+		{Index: 2, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "first"},
+		{Index: 3, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "second"},
+		{Index: 1, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "third"},
+	}, sorted.Items)
+	assert.Equal(t, "third", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+}
+
+func TestSubtitles_Renumber(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{{Index: 7, Text: "a"}, {Index: 7, Text: "b"}}}
+	renumbered := subtitles.Renumber()
+
+	assert.Equal(t, 1, renumbered.Items[0].Index)
+	assert.Equal(t, 2, renumbered.Items[1].Index)
+	assert.Equal(t, 7, subtitles.Items[0].Index, "Expected original subtitles to be unchanged")
+}
+
+func TestPipeline(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "third"},
+		{Index: 2, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "first"},
+		{Index: 3, Start: Duration(2 * time.Second), End: Duration(3 * time.Second), Text: ""},
+		{Index: 4, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "second"},
+	}}
+
+	base := NewPipeline().
+		Filter(func(c Cue) bool { return c.Text != "" }).
+		Sort()
+	upper := base.Map(func(c Cue) Cue {
+		c.Text = strings.ToUpper(c.Text)
+		return c
+	})
+	shifted := base.Then(func(s Subtitles) Subtitles { return s.Shift(time.Second) })
+
+	result := upper.Renumber().Apply(subtitles)
+	assert.Equal(t, []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "FIRST"},
+		{Index: 2, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "SECOND"},
+		{Index: 3, Start: Duration(3 * time.Second), End: Duration(4 * time.Second), Text: "THIRD"},
+	}, result.Items)
+
+	// Extending base in two ways must not make the pipelines share steps.
+	result = shifted.Apply(subtitles)
+	assert.Equal(t, []Cue{
+		{Index: 2, Start: Duration(2 * time.Second), End: Duration(3 * time.Second), Text: "first"},
+		{Index: 4, Start: Duration(2 * time.Second), End: Duration(4 * time.Second), Text: "second"},
+		{Index: 1, Start: Duration(4 * time.Second), End: Duration(5 * time.Second), Text: "third"},
+	}, result.Items)
+
+	assert.Equal(t, "third", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+	assert.Len(t, subtitles.Items, 4)
+}
+
+func TestPipeline_Empty(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "first"},
+	}}
+	result := NewPipeline().Apply(subtitles)
+	assert.Equal(t, subtitles, result)
+
+	result.Items[0].Text = "changed"
+	assert.Equal(t, "first", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// VisibleCharCount returns the number of characters displayed by the Cue:
+// formatting tags and line breaks are not counted.
+func (c Cue) VisibleCharCount() int {
+	return utf8.RuneCountInString(strings.ReplaceAll(c.PlainText(), "\n", ""))
+}
+
+// CPS returns the reading speed of the Cue in visible characters per second.
+// It returns +Inf for a cue with text that is not displayed for a positive
+// duration.
+func (c Cue) CPS() float64 {
+	return rate(float64(c.VisibleCharCount()), time.Duration(c.End-c.Start).Seconds())
+}
+
+// WPM returns the reading speed of the Cue in words per minute. It returns
+// +Inf for a cue with text that is not displayed for a positive duration.
+func (c Cue) WPM() float64 {
+	words := len(strings.Fields(c.PlainText()))
+	return rate(float64(words), time.Duration(c.End-c.Start).Minutes())
+}
+
+// rate returns count divided by elapsed, or +Inf when elapsed is not
+// positive and count is.
+func rate(count, elapsed float64) float64 {
+	if count == 0 {
+		return 0
+	}
+	if elapsed <= 0 {
+		return math.Inf(1)
+	}
+	return count / elapsed
+}
+
+// AdjustReadingSpeed returns a new Subtitles in which the End of each cue
+// exceeding maxCPS is extended until the cue meets it. A cue is never
+// extended past minGap before the start of the next cue. The positions of the
+// cues that still exceed maxCPS are returned. A non-positive maxCPS is not
+// enforced.
+func (s Subtitles) AdjustReadingSpeed(maxCPS float64, minGap time.Duration) (Subtitles, []int) {
+	items := make([]Cue, len(s.Items))
+	copy(items, s.Items)
+	if maxCPS <= 0 {
+		return Subtitles{Items: items}, nil
+	}
+
+	var unfixed []int
+	for i, cue := range items {
+		if cue.CPS() <= maxCPS {
+			continue
+		}
+
+		needed := time.Duration(math.Ceil(float64(cue.VisibleCharCount())/maxCPS*1000)) * time.Millisecond
+		end := cue.Start.Add(needed)
+		if i+1 < len(items) {
+			if limit := items[i+1].Start.Add(-minGap); limit < end {
+				end = limit
+			}
+		}
+
+		if end > cue.End {
+			items[i].End = end
+		}
+		if items[i].CPS() > maxCPS {
+			unfixed = append(unfixed, i)
+		}
+	}
+
+	return Subtitles{Items: items}, unfixed
+}
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCue_VisibleCharCount(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		{"Hello", 5},
+		{"Hello,\nWorld!", 12},
+		{"<i>Hello</i>", 5},
+		{`{\an8}<font color="#ff0000">Déjà vu</font>`, 7},
+		{"1 < 2", 5},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Cue{Text: tt.text}.VisibleCharCount())
+		})
+	}
+}
+
+func TestCue_CPS(t *testing.T) {
+	cue := Cue{Start: Duration(time.Second), End: Duration(3 * time.Second), Text: "<b>Hello</b>,\nWorld!"}
+	assert.Equal(t, 6.0, cue.CPS())
+
+	cue.End = cue.Start
+	assert.True(t, math.IsInf(cue.CPS(), 1), "Expected infinite CPS for a zero duration")
+
+	cue.Text = ""
+	assert.Equal(t, 0.0, cue.CPS())
+}
+
+func TestCue_WPM(t *testing.T) {
+	cue := Cue{Start: 0, End: Duration(3 * time.Second), Text: "<i>One two</i>\nthree four"}
+	assert.Equal(t, 80.0, cue.WPM())
+
+	cue.End = -Duration(time.Second)
+	assert.True(t, math.IsInf(cue.WPM(), 1), "Expected infinite WPM for a negative duration")
+}
+
+func TestSubtitles_AdjustReadingSpeed(t *testing.T) {
+	subtitles := Subtitles{
+		Items: []Cue{
+			// 20 characters in 1s: needs 2s at 10 CPS, room is available.
+			{Index: 1, Start: 0, End: Duration(time.Second), Text: "Twenty characters!!!"},
+			// Needs 2s but the next cue starts 1.5s later.
+			{Index: 2, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "Twenty characters!!!"},
+			{Index: 3, Start: Duration(6500 * time.Millisecond), End: Duration(10 * time.Second), Text: "Slow"},
+			// The last cue can always be extended.
+			{Index: 4, Start: Duration(20 * time.Second), End: Duration(20 * time.Second), Text: "Hi there"},
+		},
+	}
+
+	adjusted, unfixed := subtitles.AdjustReadingSpeed(10, 100*time.Millisecond)
+
+	assert.Equal(t, Duration(2*time.Second), adjusted.Items[0].End)
+	assert.Equal(t, Duration(6400*time.Millisecond), adjusted.Items[1].End)
+	assert.Equal(t, Duration(10*time.Second), adjusted.Items[2].End)
+	assert.Equal(t, Duration(20800*time.Millisecond), adjusted.Items[3].End)
+	assert.Equal(t, []int{1}, unfixed)
+
+	assert.Equal(t, Duration(time.Second), subtitles.Items[0].End, "Expected original subtitles to be unchanged")
+}
+
+func TestSubtitles_AdjustReadingSpeedRoundsUp(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{{Index: 1, Start: 0, End: Duration(time.Second), Text: "Twenty-one characters"}}}
+
+	adjusted, unfixed := subtitles.AdjustReadingSpeed(17, 0)
+
+	assert.Equal(t, Duration(1236*time.Millisecond), adjusted.Items[0].End)
+	assert.LessOrEqual(t, adjusted.Items[0].CPS(), 17.0)
+	assert.Empty(t, unfixed)
+}
+
+func TestSubtitles_AdjustReadingSpeedNoLimit(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{{Index: 1, Start: 0, End: Duration(time.Second), Text: "Twenty characters!!!"}}}
+
+	for _, maxCPS := range []float64{0, -1} {
+		adjusted, unfixed := subtitles.AdjustReadingSpeed(maxCPS, 0)
+
+		assert.Equal(t, subtitles.Items, adjusted.Items, "Expected cues to be unchanged for maxCPS %v", maxCPS)
+		assert.Empty(t, unfixed)
+	}
+}
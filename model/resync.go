@@ -0,0 +1,233 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// Anchor pairs a cue, by its position in Subtitles.Items, with the time it
+// should start at.
+type Anchor struct {
+	CueIndex     int
+	CorrectStart Duration
+}
+
+var (
+	// ErrAnchorOutOfRange is returned when an anchor refers to a cue that
+	// does not exist.
+	ErrAnchorOutOfRange = errors.New("anchor cue index out of range")
+	// ErrNotEnoughAnchors is returned when the anchors cannot determine a
+	// transform, for instance when there are none or all their cues start
+	// at the same time.
+	ErrNotEnoughAnchors = errors.New("not enough distinct anchors")
+)
+
+// linearMap maps a time t to scale*t + offset.
+type linearMap struct {
+	scale  float64
+	offset time.Duration
+}
+
+// apply maps d and rounds the result to the nearest millisecond.
+func (m linearMap) apply(d Duration) Duration {
+	t := m.scale*float64(d) + float64(m.offset)
+	return Duration(time.Duration(math.Round(t/float64(time.Millisecond))) * time.Millisecond)
+}
+
+// applyCue returns c with its Start and End mapped by m.
+func (m linearMap) applyCue(c Cue) Cue {
+	c.Start = m.apply(c.Start)
+	c.End = m.apply(c.End)
+	return c
+}
+
+// applyAll returns a new Subtitles with every cue mapped by m.
+func (m linearMap) applyAll(s Subtitles) Subtitles {
+	mapped := make([]Cue, len(s.Items))
+	for i, cue := range s.Items {
+		mapped[i] = m.applyCue(cue)
+	}
+	return Subtitles{Items: mapped}
+}
+
+// Resync returns a new Subtitles linearly retimed so that the cue at
+// position ref1Index starts at newTime1 and the cue at position ref2Index
+// starts at newTime2. Every cue is scaled and shifted by the same transform,
+// which corrects both a constant offset and a linear drift.
+func (s Subtitles) Resync(ref1Index int, newTime1 Duration, ref2Index int, newTime2 Duration) (Subtitles, error) {
+	return s.ResyncFit([]Anchor{
+		{CueIndex: ref1Index, CorrectStart: newTime1},
+		{CueIndex: ref2Index, CorrectStart: newTime2},
+	})
+}
+
+// ResyncFit returns a new Subtitles linearly retimed by the transform that
+// best fits the given anchors in the least-squares sense. With a single
+// anchor, the track is only shifted; with two, the fit is exact.
+func (s Subtitles) ResyncFit(anchors []Anchor) (Subtitles, error) {
+	m, err := s.fit(anchors)
+	if err != nil {
+		return s, err
+	}
+	return m.applyAll(s), nil
+}
+
+// fit computes the least-squares linear map from the current start of the
+// anchored cues to their correct start.
+func (s Subtitles) fit(anchors []Anchor) (linearMap, error) {
+	if len(anchors) == 0 {
+		return linearMap{}, ErrNotEnoughAnchors
+	}
+	for _, a := range anchors {
+		if a.CueIndex < 0 || a.CueIndex >= len(s.Items) {
+			return linearMap{}, ErrAnchorOutOfRange
+		}
+	}
+
+	if len(anchors) == 1 {
+		a := anchors[0]
+		return linearMap{scale: 1, offset: time.Duration(a.CorrectStart - s.Items[a.CueIndex].Start)}, nil
+	}
+
+	n := float64(len(anchors))
+	var sumX, sumY float64
+	for _, a := range anchors {
+		sumX += float64(s.Items[a.CueIndex].Start)
+		sumY += float64(a.CorrectStart)
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var sxx, sxy float64
+	for _, a := range anchors {
+		dx := float64(s.Items[a.CueIndex].Start) - meanX
+		dy := float64(a.CorrectStart) - meanY
+		sxx += dx * dx
+		sxy += dx * dy
+	}
+	if sxx == 0 {
+		return linearMap{}, ErrNotEnoughAnchors
+	}
+
+	scale := sxy / sxx
+	return linearMap{scale: scale, offset: time.Duration(math.Round(meanY - scale*meanX))}, nil
+}
+
+// ResyncMode selects how ResyncAnchors corrects cues between anchors.
+type ResyncMode int
+
+const (
+	// PiecewiseLinear interpolates linearly between consecutive anchors.
+	PiecewiseLinear ResyncMode = iota
+	// PiecewiseConstant shifts every cue by the offset of the closest
+	// anchor at or before it.
+	PiecewiseConstant
+)
+
+// ResyncSegment reports the correction applied by ResyncAnchors to a range
+// of cues, given by their positions in Subtitles.Items.
+type ResyncSegment struct {
+	FirstCue int
+	LastCue  int
+	// Scale is the speed factor applied to the segment, 1 for a pure shift.
+	Scale float64
+	// Offset is the shift applied to the start of the anchor cue opening the
+	// segment.
+	Offset time.Duration
+}
+
+// ResyncAnchors returns a new Subtitles corrected segment by segment between
+// consecutive anchors, along with the correction applied to each segment.
+//
+// In PiecewiseLinear mode, each segment spans from one anchor to the next
+// and is mapped by the linear transform through both; cues before the first
+// anchor and after the last one are extrapolated from the nearest segment. A
+// single anchor shifts the whole track. In PiecewiseConstant mode, each
+// anchor opens a segment shifted by its own offset, the first segment also
+// covering the cues before the first anchor.
+func (s Subtitles) ResyncAnchors(anchors []Anchor, mode ResyncMode) (Subtitles, []ResyncSegment, error) {
+	if len(anchors) == 0 {
+		return s, nil, ErrNotEnoughAnchors
+	}
+
+	sorted := make([]Anchor, len(anchors))
+	copy(sorted, anchors)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].CueIndex < sorted[j].CueIndex
+	})
+
+	for i, a := range sorted {
+		if a.CueIndex < 0 || a.CueIndex >= len(s.Items) {
+			return s, nil, ErrAnchorOutOfRange
+		}
+		if i > 0 && a.CueIndex == sorted[i-1].CueIndex {
+			return s, nil, ErrNotEnoughAnchors
+		}
+	}
+
+	if mode == PiecewiseConstant || len(sorted) == 1 {
+		return s.resyncConstant(sorted)
+	}
+	return s.resyncLinear(sorted)
+}
+
+// resyncConstant shifts each segment opened by an anchor by its offset.
+func (s Subtitles) resyncConstant(anchors []Anchor) (Subtitles, []ResyncSegment, error) {
+	segments := make([]ResyncSegment, len(anchors))
+	for i, a := range anchors {
+		segments[i] = ResyncSegment{
+			FirstCue: a.CueIndex,
+			LastCue:  len(s.Items) - 1,
+			Scale:    1,
+			Offset:   time.Duration(a.CorrectStart - s.Items[a.CueIndex].Start),
+		}
+		if i > 0 {
+			segments[i-1].LastCue = a.CueIndex - 1
+		}
+	}
+	segments[0].FirstCue = 0
+
+	resynced := make([]Cue, len(s.Items))
+	for _, seg := range segments {
+		for i := seg.FirstCue; i <= seg.LastCue; i++ {
+			resynced[i] = s.Items[i].Shift(seg.Offset)
+		}
+	}
+
+	return Subtitles{Items: resynced}, segments, nil
+}
+
+// resyncLinear maps each segment between two consecutive anchors with the
+// linear transform through both.
+func (s Subtitles) resyncLinear(anchors []Anchor) (Subtitles, []ResyncSegment, error) {
+	maps := make([]linearMap, len(anchors)-1)
+	segments := make([]ResyncSegment, len(anchors)-1)
+
+	for i := range maps {
+		m, err := s.fit(anchors[i : i+2])
+		if err != nil {
+			return s, nil, err
+		}
+		maps[i] = m
+
+		a := anchors[i]
+		segments[i] = ResyncSegment{
+			FirstCue: a.CueIndex,
+			LastCue:  anchors[i+1].CueIndex - 1,
+			Scale:    m.scale,
+			Offset:   time.Duration(m.apply(s.Items[a.CueIndex].Start) - s.Items[a.CueIndex].Start),
+		}
+	}
+	segments[0].FirstCue = 0
+	segments[len(segments)-1].LastCue = len(s.Items) - 1
+
+	resynced := make([]Cue, len(s.Items))
+	for i, seg := range segments {
+		for j := seg.FirstCue; j <= seg.LastCue; j++ {
+			resynced[j] = maps[i].applyCue(s.Items[j])
+		}
+	}
+
+	return Subtitles{Items: resynced}, segments, nil
+}
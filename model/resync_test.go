@@ -0,0 +1,184 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubtitles_Resync(t *testing.T) {
+	subtitles := Subtitles{
+		Items: []Cue{
+			{Index: 1, Start: Duration(10 * time.Second), End: Duration(12 * time.Second), Text: "First"},
+			{Index: 2, Start: Duration(60 * time.Second), End: Duration(63 * time.Second), Text: "Second"},
+			{Index: 3, Start: Duration(110 * time.Second), End: Duration(111 * time.Second), Text: "Third"},
+		},
+	}
+
+	// First cue must start 1s later, last cue 6s later: scale 1.05, offset 0.5s.
+	resynced, err := subtitles.Resync(0, Duration(11*time.Second), 2, Duration(116*time.Second))
+	assert.NoError(t, err, "Expected no error from Resync")
+
+	assert.Equal(t, Duration(11*time.Second), resynced.Items[0].Start)
+	assert.Equal(t, Duration(13*time.Second+100*time.Millisecond), resynced.Items[0].End)
+	assert.Equal(t, Duration(63*time.Second+500*time.Millisecond), resynced.Items[1].Start)
+	assert.Equal(t, Duration(66*time.Second+650*time.Millisecond), resynced.Items[1].End)
+	assert.Equal(t, Duration(116*time.Second), resynced.Items[2].Start)
+	assert.Equal(t, Duration(117*time.Second+50*time.Millisecond), resynced.Items[2].End)
+	assert.Equal(t, "Second", resynced.Items[1].Text)
+
+	assert.Equal(t, Duration(10*time.Second), subtitles.Items[0].Start, "Expected original subtitles to be unchanged")
+}
+
+func TestSubtitles_ResyncErrors(t *testing.T) {
+	subtitles := Subtitles{
+		Items: []Cue{
+			{Index: 1, Start: Duration(10 * time.Second), End: Duration(12 * time.Second), Text: "First"},
+			{Index: 2, Start: Duration(60 * time.Second), End: Duration(63 * time.Second), Text: "Second"},
+			{Index: 3, Start: Duration(110 * time.Second), End: Duration(111 * time.Second), Text: "Third"},
+		},
+	}
+
+	_, err := subtitles.Resync(0, 0, 3, 0)
+	assert.ErrorIs(t, err, ErrAnchorOutOfRange)
+
+	_, err = subtitles.Resync(1, 0, 1, Duration(time.Second))
+	assert.ErrorIs(t, err, ErrNotEnoughAnchors)
+
+	_, err = subtitles.ResyncFit(nil)
+	assert.ErrorIs(t, err, ErrNotEnoughAnchors)
+}
+
+func TestSubtitles_ResyncFit(t *testing.T) {
+	subtitles := Subtitles{
+		Items: []Cue{
+			{Index: 1, Start: Duration(10 * time.Second), End: Duration(12 * time.Second), Text: "First"},
+			{Index: 2, Start: Duration(60 * time.Second), End: Duration(63 * time.Second), Text: "Second"},
+			{Index: 3, Start: Duration(110 * time.Second), End: Duration(111 * time.Second), Text: "Third"},
+		},
+	}
+
+	// A single anchor shifts the whole track.
+	resynced, err := subtitles.ResyncFit([]Anchor{{CueIndex: 1, CorrectStart: Duration(58 * time.Second)}})
+	assert.NoError(t, err, "Expected no error from ResyncFit")
+	assert.Equal(t, Duration(8*time.Second), resynced.Items[0].Start)
+	assert.Equal(t, Duration(108*time.Second), resynced.Items[2].Start)
+
+	// Anchors slightly off the line scale 1.05, offset 0.5s are fitted
+	// back to it.
+	resynced, err = subtitles.ResyncFit([]Anchor{
+		{CueIndex: 0, CorrectStart: Duration(11*time.Second - 40*time.Millisecond)},
+		{CueIndex: 1, CorrectStart: Duration(63*time.Second + 500*time.Millisecond + 80*time.Millisecond)},
+		{CueIndex: 2, CorrectStart: Duration(116*time.Second - 40*time.Millisecond)},
+	})
+	assert.NoError(t, err, "Expected no error from ResyncFit")
+	assert.Equal(t, Duration(11*time.Second), resynced.Items[0].Start)
+	assert.Equal(t, Duration(63*time.Second+500*time.Millisecond), resynced.Items[1].Start)
+	assert.Equal(t, Duration(116*time.Second), resynced.Items[2].Start)
+}
+
+func TestSubtitles_ResyncAnchorsConstant(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(0 * time.Second), End: Duration(2 * time.Second), Text: "Cue"},
+		{Index: 2, Start: Duration(10 * time.Second), End: Duration(12 * time.Second), Text: "Cue"},
+		{Index: 3, Start: Duration(20 * time.Second), End: Duration(22 * time.Second), Text: "Cue"},
+		{Index: 4, Start: Duration(30 * time.Second), End: Duration(32 * time.Second), Text: "Cue"},
+		{Index: 5, Start: Duration(40 * time.Second), End: Duration(42 * time.Second), Text: "Cue"},
+		{Index: 6, Start: Duration(50 * time.Second), End: Duration(52 * time.Second), Text: "Cue"},
+		{Index: 7, Start: Duration(60 * time.Second), End: Duration(62 * time.Second), Text: "Cue"},
+		{Index: 8, Start: Duration(70 * time.Second), End: Duration(72 * time.Second), Text: "Cue"},
+	}}
+
+	resynced, segments, err := subtitles.ResyncAnchors([]Anchor{
+		{CueIndex: 5, CorrectStart: Duration(47 * time.Second)},
+		{CueIndex: 2, CorrectStart: Duration(22 * time.Second)},
+	}, PiecewiseConstant)
+	assert.NoError(t, err, "Expected no error from ResyncAnchors")
+
+	assert.Equal(t, []ResyncSegment{
+		{FirstCue: 0, LastCue: 4, Scale: 1, Offset: 2 * time.Second},
+		{FirstCue: 5, LastCue: 7, Scale: 1, Offset: -3 * time.Second},
+	}, segments)
+
+	expectedStarts := []time.Duration{2, 12, 22, 32, 42, 47, 57, 67}
+	for i, cue := range resynced.Items {
+		assert.Equal(t, Duration(expectedStarts[i]*time.Second), cue.Start, "[%d] unexpected start", i)
+		assert.Equal(t, cue.Start+Duration(2*time.Second), cue.End, "[%d] unexpected end", i)
+	}
+	assert.Equal(t, Duration(0), subtitles.Items[0].Start, "Expected original subtitles to be unchanged")
+}
+
+func TestSubtitles_ResyncAnchorsLinear(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(0 * time.Second), End: Duration(2 * time.Second), Text: "Cue"},
+		{Index: 2, Start: Duration(10 * time.Second), End: Duration(12 * time.Second), Text: "Cue"},
+		{Index: 3, Start: Duration(20 * time.Second), End: Duration(22 * time.Second), Text: "Cue"},
+		{Index: 4, Start: Duration(30 * time.Second), End: Duration(32 * time.Second), Text: "Cue"},
+		{Index: 5, Start: Duration(40 * time.Second), End: Duration(42 * time.Second), Text: "Cue"},
+		{Index: 6, Start: Duration(50 * time.Second), End: Duration(52 * time.Second), Text: "Cue"},
+		{Index: 7, Start: Duration(60 * time.Second), End: Duration(62 * time.Second), Text: "Cue"},
+		{Index: 8, Start: Duration(70 * time.Second), End: Duration(72 * time.Second), Text: "Cue"},
+	}}
+
+	resynced, segments, err := subtitles.ResyncAnchors([]Anchor{
+		{CueIndex: 1, CorrectStart: Duration(11 * time.Second)},
+		{CueIndex: 3, CorrectStart: Duration(31 * time.Second)},
+		{CueIndex: 5, CorrectStart: Duration(41 * time.Second)},
+	}, PiecewiseLinear)
+	assert.NoError(t, err, "Expected no error from ResyncAnchors")
+
+	assert.Equal(t, []ResyncSegment{
+		{FirstCue: 0, LastCue: 2, Scale: 1, Offset: time.Second},
+		{FirstCue: 3, LastCue: 7, Scale: 0.5, Offset: time.Second},
+	}, segments)
+
+	// Cue 0 is extrapolated from the first segment, cues 6 and 7 from the
+	// last one.
+	expectedStarts := []time.Duration{1000, 11000, 21000, 31000, 36000, 41000, 46000, 51000}
+	expectedEnds := []time.Duration{3000, 13000, 23000, 32000, 37000, 42000, 47000, 52000}
+	for i, cue := range resynced.Items {
+		assert.Equal(t, Duration(expectedStarts[i]*time.Millisecond), cue.Start, "[%d] unexpected start", i)
+		assert.Equal(t, Duration(expectedEnds[i]*time.Millisecond), cue.End, "[%d] unexpected end", i)
+	}
+}
+
+func TestSubtitles_ResyncAnchorsSingle(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(0 * time.Second), End: Duration(2 * time.Second), Text: "Cue"},
+		{Index: 2, Start: Duration(10 * time.Second), End: Duration(12 * time.Second), Text: "Cue"},
+		{Index: 3, Start: Duration(20 * time.Second), End: Duration(22 * time.Second), Text: "Cue"},
+		{Index: 4, Start: Duration(30 * time.Second), End: Duration(32 * time.Second), Text: "Cue"},
+		{Index: 5, Start: Duration(40 * time.Second), End: Duration(42 * time.Second), Text: "Cue"},
+		{Index: 6, Start: Duration(50 * time.Second), End: Duration(52 * time.Second), Text: "Cue"},
+		{Index: 7, Start: Duration(60 * time.Second), End: Duration(62 * time.Second), Text: "Cue"},
+		{Index: 8, Start: Duration(70 * time.Second), End: Duration(72 * time.Second), Text: "Cue"},
+	}}
+
+	resynced, segments, err := subtitles.ResyncAnchors([]Anchor{{CueIndex: 4, CorrectStart: Duration(45 * time.Second)}}, PiecewiseLinear)
+	assert.NoError(t, err, "Expected no error from ResyncAnchors")
+	assert.Equal(t, []ResyncSegment{{FirstCue: 0, LastCue: 7, Scale: 1, Offset: 5 * time.Second}}, segments)
+	assert.Equal(t, Duration(5*time.Second), resynced.Items[0].Start)
+}
+
+func TestSubtitles_ResyncAnchorsErrors(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(0 * time.Second), End: Duration(2 * time.Second), Text: "Cue"},
+		{Index: 2, Start: Duration(10 * time.Second), End: Duration(12 * time.Second), Text: "Cue"},
+		{Index: 3, Start: Duration(20 * time.Second), End: Duration(22 * time.Second), Text: "Cue"},
+		{Index: 4, Start: Duration(30 * time.Second), End: Duration(32 * time.Second), Text: "Cue"},
+		{Index: 5, Start: Duration(40 * time.Second), End: Duration(42 * time.Second), Text: "Cue"},
+		{Index: 6, Start: Duration(50 * time.Second), End: Duration(52 * time.Second), Text: "Cue"},
+		{Index: 7, Start: Duration(60 * time.Second), End: Duration(62 * time.Second), Text: "Cue"},
+		{Index: 8, Start: Duration(70 * time.Second), End: Duration(72 * time.Second), Text: "Cue"},
+	}}
+
+	_, _, err := subtitles.ResyncAnchors(nil, PiecewiseLinear)
+	assert.ErrorIs(t, err, ErrNotEnoughAnchors)
+
+	_, _, err = subtitles.ResyncAnchors([]Anchor{{CueIndex: 8}}, PiecewiseConstant)
+	assert.ErrorIs(t, err, ErrAnchorOutOfRange)
+
+	_, _, err = subtitles.ResyncAnchors([]Anchor{{CueIndex: 2}, {CueIndex: 2}}, PiecewiseLinear)
+	assert.ErrorIs(t, err, ErrNotEnoughAnchors)
+}
@@ -0,0 +1,394 @@
+package model
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// conjunctions are words a line may start with, as they usually begin a new
+// clause.
+var conjunctions = map[string]bool{
+	"and": true, "or": true, "but": true, "because": true, "so": true,
+	"which": true, "that": true, "who": true, "when": true, "while": true, "if": true,
+	"et": true, "ou": true, "mais": true, "donc": true, "car": true,
+	"qui": true, "que": true, "quand": true, "parce": true,
+	"y": true, "o": true, "pero": true, "porque": true,
+	"und": true, "oder": true, "aber": true, "weil": true, "dass": true,
+	"e": true, "ma": true, "perché": true,
+}
+
+// weakEndings are words a line should not end with, as they belong with the
+// word that follows them.
+var weakEndings = map[string]bool{
+	"a": true, "an": true, "the": true, "of": true, "to": true, "in": true,
+	"on": true, "at": true, "for": true, "with": true, "my": true, "your": true,
+	"le": true, "la": true, "les": true, "un": true, "une": true, "de": true,
+	"des": true, "du": true, "au": true, "aux": true, "à": true, "mon": true,
+	"el": true, "los": true, "las": true, "der": true, "die": true, "das": true,
+	"ein": true, "eine": true, "il": true, "lo": true,
+}
+
+// Costs of the line breaks considered by Rewrap, added to the deviation of
+// each line width from the average, in columns, times costImbalance.
+const (
+	costImbalance       = 2
+	costTopHeavy        = 2
+	costAfterSentence   = -60
+	costAfterClause     = -40
+	costBeforeConjuncts = -30
+	costAfterWeakWord   = 50
+	costOrphan          = 80
+)
+
+// wrapToken is a word, or a single wide character, of the text to wrap.
+type wrapToken struct {
+	// start and end are the byte offsets of the token in the plain text.
+	start, end int
+	text       string
+	width      int
+	// space reports whether the token is separated from the previous one by
+	// white space.
+	space bool
+}
+
+// Rewrap returns a new Cue whose text is broken into at most maxLines lines
+// of at most maxLineLen columns, as measured by TextWidth. Text that fits on
+// a single line is not broken. Otherwise, breaks are chosen to use as few
+// lines as possible, preferring breaks after punctuation and before
+// conjunctions, avoiding breaks after articles and lines of a single word,
+// and keeping lines balanced, or longer at the bottom. Formatting tags are
+// kept around the same words.
+//
+// Dialogue cues, where each line starts with a dash, keep their lines. The
+// Cue is returned unchanged, along with false, if its text cannot fit. A
+// non-positive limit is not enforced.
+func (c Cue) Rewrap(maxLineLen, maxLines int) (Cue, bool) {
+	rich := ParseRichText(c.Text)
+	plain := rich.PlainText()
+
+	if isDialogue(plain) {
+		lines := strings.Split(plain, "\n")
+		fits := maxLines <= 0 || len(lines) <= maxLines
+		for _, line := range lines {
+			fits = fits && (maxLineLen <= 0 || TextWidth(strings.TrimSpace(line)) <= maxLineLen)
+		}
+		return c, fits
+	}
+
+	tokens := tokenize(plain)
+	if len(tokens) == 0 {
+		return c, true
+	}
+
+	breaks, ok := chooseBreaks(tokens, maxLineLen, maxLines)
+	if !ok {
+		return c, false
+	}
+
+	c.Text = applyBreaks(rich, tokens, breaks).String()
+	return c, true
+}
+
+// Rewrap returns a new Subtitles with the text of every cue rewrapped by
+// Cue.Rewrap, along with the positions of the cues that do not fit.
+func (s Subtitles) Rewrap(maxLineLen, maxLines int) (Subtitles, []int) {
+	items := make([]Cue, len(s.Items))
+	var unfit []int
+	for i, cue := range s.Items {
+		var ok bool
+		items[i], ok = cue.Rewrap(maxLineLen, maxLines)
+		if !ok {
+			unfit = append(unfit, i)
+		}
+	}
+	return Subtitles{Items: items}, unfit
+}
+
+// isDialogue reports whether text has several lines all starting with a
+// dash.
+func isDialogue(text string) bool {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if len(lines) < 2 {
+		return false
+	}
+	for _, line := range lines {
+		if !startsDialogue(line) {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenize splits plain text into words separated by white space. Wide
+// characters, which are written without spaces, form a token each, except
+// punctuation and combining marks that stay with the previous token.
+func tokenize(plain string) []wrapToken {
+	var tokens []wrapToken
+	space, prevWide := false, false
+	for i, r := range plain {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+
+		wide := isWide(r)
+		attach := len(tokens) > 0 && !space &&
+			(unicode.IsPunct(r) || unicode.In(r, unicode.Mn, unicode.Me) || (!wide && !prevWide))
+		if !attach {
+			tokens = append(tokens, wrapToken{start: i, space: space && len(tokens) > 0})
+		}
+
+		t := &tokens[len(tokens)-1]
+		t.end = i + utf8.RuneLen(r)
+		t.text = plain[t.start:t.end]
+		space, prevWide = false, wide
+	}
+
+	for i := range tokens {
+		tokens[i].width = TextWidth(tokens[i].text)
+	}
+	return tokens
+}
+
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
+}
+
+// lineWidth returns the width of the line made of tokens.
+func lineWidth(tokens []wrapToken) int {
+	width := 0
+	for i, t := range tokens {
+		width += t.width
+		if i > 0 && t.space {
+			width++
+		}
+	}
+	return width
+}
+
+// chooseBreaks returns, for each token, whether a line break goes before
+// it, using the fewest lines that fit and the breaks of lowest cost.
+func chooseBreaks(tokens []wrapToken, maxLineLen, maxLines int) ([]bool, bool) {
+	if maxLineLen <= 0 || lineWidth(tokens) <= maxLineLen {
+		return make([]bool, len(tokens)), true
+	}
+
+	w := newLineWidths(tokens)
+	lines := w.fewestLines(maxLineLen)
+	if lines == 0 || (maxLines > 0 && lines > maxLines) {
+		return nil, false
+	}
+
+	breaks := make([]bool, len(tokens))
+	for _, start := range w.bestStarts(lines, maxLineLen)[1:] {
+		breaks[start] = true
+	}
+	return breaks, true
+}
+
+// lineWidths computes the width of any line of tokens in constant time.
+type lineWidths struct {
+	tokens []wrapToken
+	// widths and spaces hold the total width and the number of tokens
+	// preceded by white space of the first n tokens, at index n.
+	widths, spaces []int
+}
+
+func newLineWidths(tokens []wrapToken) lineWidths {
+	w := lineWidths{tokens: tokens, widths: make([]int, len(tokens)+1), spaces: make([]int, len(tokens)+1)}
+	for i, t := range tokens {
+		w.widths[i+1] = w.widths[i] + t.width
+		w.spaces[i+1] = w.spaces[i]
+		if i > 0 && t.space {
+			w.spaces[i+1]++
+		}
+	}
+	return w
+}
+
+// of returns the width of the line made of the tokens from start to end,
+// as lineWidth does.
+func (w lineWidths) of(start, end int) int {
+	return w.widths[end] - w.widths[start] + w.spaces[end] - w.spaces[start+1]
+}
+
+// fewestLines returns the smallest number of lines of at most maxLineLen
+// columns the tokens fit on, or 0 if a token is wider than maxLineLen.
+// Filling each line as much as possible uses the fewest lines.
+func (w lineWidths) fewestLines(maxLineLen int) int {
+	lines := 0
+	for start := 0; start < len(w.tokens); lines++ {
+		end := start + 1
+		if w.of(start, end) > maxLineLen {
+			return 0
+		}
+		for end < len(w.tokens) && w.of(start, end+1) <= maxLineLen {
+			end++
+		}
+		start = end
+	}
+	return lines
+}
+
+// bestStarts returns the index of the first token of each of the given
+// number of lines of at most maxLineLen columns, for the breaks of lowest
+// cost. The tokens must fit on that many lines.
+//
+// The lines are chosen by dynamic programming: as the cost of a line only
+// depends on the line itself and on the width of the previous one, the best
+// breaks up to a line are kept for each way of laying out that line.
+func (w lineWidths) bestStarts(lines, maxLineLen int) []int {
+	n := len(w.tokens)
+
+	// Lines that fit hold at most span tokens.
+	span := 1
+	for start := 0; start < n; start++ {
+		for end := start + span + 1; end <= n && w.of(start, end) <= maxLineLen; end++ {
+			span = end - start
+		}
+	}
+
+	// Every break but those between wide characters replaces a space, which
+	// does not count in the width of the lines.
+	total := float64(w.of(0, n))
+	if n > 1 {
+		total -= float64(lines-1) * float64(w.spaces[n]) / float64(n-1)
+	}
+	average := total / float64(lines)
+
+	// best[k][start*span+length-1] is the lowest cost of the first k+1 lines,
+	// the last of which holds length tokens from start, and prev is the start
+	// of the line before it.
+	type state struct {
+		cost  float64
+		prev  int
+		valid bool
+	}
+	best := make([][]state, lines)
+	for k := range best {
+		best[k] = make([]state, n*span)
+	}
+
+	for end := 1; end <= span && end <= n && w.of(0, end) <= maxLineLen; end++ {
+		best[0][end-1] = state{cost: w.lineCost(0, end, average), prev: -1, valid: true}
+	}
+	for k := 1; k < lines; k++ {
+		for start := 0; start < n; start++ {
+			for length := 1; length <= span && start+length < n; length++ {
+				s := best[k-1][start*span+length-1]
+				if !s.valid {
+					continue
+				}
+				mid := start + length
+				prevWidth := w.of(start, mid)
+				for end := mid + 1; end <= n && end-mid <= span && w.of(mid, end) <= maxLineLen; end++ {
+					width := w.of(mid, end)
+					cost := s.cost + w.lineCost(mid, end, average)
+					if prevWidth > width {
+						cost += costTopHeavy * float64(prevWidth-width)
+					}
+					next := &best[k][mid*span+end-mid-1]
+					if !next.valid || cost < next.cost {
+						*next = state{cost: cost, prev: start, valid: true}
+					}
+				}
+			}
+		}
+	}
+
+	// Walk back from the cheapest last line ending with the last token.
+	last := -1
+	for start := n - span; start < n; start++ {
+		if start < 0 {
+			continue
+		}
+		s := best[lines-1][start*span+n-start-1]
+		if s.valid && (last < 0 || s.cost < best[lines-1][last*span+n-last-1].cost) {
+			last = start
+		}
+	}
+
+	starts := make([]int, lines)
+	end := n
+	for k, start := lines-1, last; k >= 0; k-- {
+		starts[k] = start
+		start, end = best[k][start*span+end-start-1].prev, start
+	}
+	return starts
+}
+
+// lineCost rates the line made of the tokens from start to end, regardless
+// of the lines around it; lower is better.
+func (w lineWidths) lineCost(start, end int, average float64) float64 {
+	cost := costImbalance * math.Abs(float64(w.of(start, end))-average)
+	if end-start == 1 {
+		cost += costOrphan
+	}
+	if start == 0 {
+		return cost
+	}
+
+	prev := w.tokens[start-1].text
+	switch lastRune(prev) {
+	case '.', '!', '?', '…', '。', '！', '？':
+		cost += costAfterSentence
+	case ',', ';', ':', '、', '，':
+		cost += costAfterClause
+	}
+	if conjunctions[normalizeWord(w.tokens[start].text)] {
+		cost += costBeforeConjuncts
+	}
+	if weakEndings[normalizeWord(prev)] {
+		cost += costAfterWeakWord
+	}
+	return cost
+}
+
+// normalizeWord returns word in lower case without surrounding punctuation.
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, unicode.IsPunct))
+}
+
+// applyBreaks returns rich with the white space between tokens replaced by a
+// line break where breaks tells, and by a single space elsewhere. Leading
+// and trailing white space is removed.
+func applyBreaks(rich RichText, tokens []wrapToken, breaks []bool) RichText {
+	// next maps the byte offset at which each token but the last ends to the
+	// position of the following token.
+	next := make(map[int]int, len(tokens))
+	for k := 1; k < len(tokens); k++ {
+		next[tokens[k-1].end] = k
+	}
+	first, last := tokens[0].start, tokens[len(tokens)-1].end
+
+	result := RichText{Alignment: rich.Alignment}
+	offset := 0
+	for _, span := range rich.Spans {
+		var b strings.Builder
+		for _, r := range span.Text {
+			o := offset
+			offset += utf8.RuneLen(r)
+			if o < first || o >= last {
+				continue
+			}
+
+			if k, ok := next[o]; ok {
+				if breaks[k] {
+					b.WriteByte('\n')
+				} else if tokens[k].space {
+					b.WriteByte(' ')
+				}
+			}
+			if !unicode.IsSpace(r) {
+				b.WriteRune(r)
+			}
+		}
+
+		span.Text = b.String()
+		result.Spans = appendSpan(result.Spans, span)
+	}
+	return result
+}
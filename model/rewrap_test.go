@@ -0,0 +1,189 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCue_Rewrap(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxLen   int
+		maxLines int
+		expected string
+		fits     bool
+	}{
+		{
+			name:     "fits on one line",
+			text:     "Short enough.",
+			maxLen:   42,
+			maxLines: 2,
+			expected: "Short enough.",
+			fits:     true,
+		},
+		{
+			name:     "joins short lines",
+			text:     "Short\nenough.",
+			maxLen:   42,
+			maxLines: 2,
+			expected: "Short enough.",
+			fits:     true,
+		},
+		{
+			name:     "balanced",
+			text:     "I don't know what you are talking about right now",
+			maxLen:   32,
+			maxLines: 2,
+			expected: "I don't know what you are\ntalking about right now",
+			fits:     true,
+		},
+		{
+			name:     "after punctuation",
+			text:     "Well, I thought we could go to the beach tomorrow",
+			maxLen:   42,
+			maxLines: 2,
+			expected: "Well, I thought we could\ngo to the beach tomorrow",
+			fits:     true,
+		},
+		{
+			name:     "after sentence",
+			text:     "Come here. I need to show you something important",
+			maxLen:   42,
+			maxLines: 2,
+			expected: "Come here.\nI need to show you something important",
+			fits:     true,
+		},
+		{
+			name:     "before conjunction",
+			text:     "We should leave right now and never come back here",
+			maxLen:   42,
+			maxLines: 2,
+			expected: "We should leave right now\nand never come back here",
+			fits:     true,
+		},
+		{
+			name:     "not after an article",
+			text:     "He gave me the biggest present of them all",
+			maxLen:   30,
+			maxLines: 2,
+			expected: "He gave me the biggest\npresent of them all",
+			fits:     true,
+		},
+		{
+			name:     "bottom heavy",
+			text:     "aaaa bbbb cccc dddd eeee",
+			maxLen:   15,
+			maxLines: 2,
+			expected: "aaaa bbbb\ncccc dddd eeee",
+			fits:     true,
+		},
+		{
+			name:     "three lines",
+			text:     "one two three four five six seven eight nine",
+			maxLen:   16,
+			maxLines: 3,
+			expected: "one two three\nfour five six\nseven eight nine",
+			fits:     true,
+		},
+		{
+			name:     "keeps tags around words",
+			text:     "<i>I don't know what</i> you are <b>talking about</b> right now",
+			maxLen:   32,
+			maxLines: 2,
+			expected: "<i>I don't know what</i> you are\n<b>talking about</b> right now",
+			fits:     true,
+		},
+		{
+			name:     "keeps alignment",
+			text:     `{\an8}I don't know what you are talking about right now`,
+			maxLen:   32,
+			maxLines: 2,
+			expected: "{\\an8}I don't know what you are\ntalking about right now",
+			fits:     true,
+		},
+		{
+			name:     "wide characters",
+			text:     "今日はとても良い天気ですね。",
+			maxLen:   16,
+			maxLines: 2,
+			expected: "今日はとても良\nい天気ですね。",
+			fits:     true,
+		},
+		{
+			name:     "dialogue keeps lines",
+			text:     "- Hello.\n- Hi, how are you?",
+			maxLen:   42,
+			maxLines: 2,
+			expected: "- Hello.\n- Hi, how are you?",
+			fits:     true,
+		},
+		{
+			name:     "does not fit",
+			text:     "This sentence is far too long to fit in two short lines",
+			maxLen:   15,
+			maxLines: 2,
+			expected: "This sentence is far too long to fit in two short lines",
+			fits:     false,
+		},
+		{
+			name:     "word longer than a line",
+			text:     "Supercalifragilisticexpialidocious",
+			maxLen:   20,
+			maxLines: 2,
+			expected: "Supercalifragilisticexpialidocious",
+			fits:     false,
+		},
+		{
+			name:     "no limit",
+			text:     "Any\ntext",
+			maxLen:   0,
+			maxLines: 0,
+			expected: "Any text",
+			fits:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cue := Cue{Index: 1, Start: 0, End: Duration(time.Second), Text: tt.text}
+			rewrapped, fits := cue.Rewrap(tt.maxLen, tt.maxLines)
+			assert.Equal(t, tt.expected, rewrapped.Text)
+			assert.Equal(t, tt.fits, fits)
+		})
+	}
+}
+
+func TestCue_RewrapLongText(t *testing.T) {
+	text := strings.TrimSpace(strings.Repeat("We go on. ", 100))
+	cue := Cue{Index: 1, Start: 0, End: Duration(time.Second), Text: text}
+
+	start := time.Now()
+	rewrapped, fits := cue.Rewrap(42, 0)
+	assert.Less(t, time.Since(start), time.Second, "Expected long text to be rewrapped quickly")
+
+	assert.True(t, fits)
+	lines := strings.Split(rewrapped.Text, "\n")
+	assert.Len(t, lines, 25)
+	for _, line := range lines {
+		assert.LessOrEqual(t, TextWidth(line), 42)
+	}
+	assert.Equal(t, text, strings.ReplaceAll(rewrapped.Text, "\n", " "))
+}
+
+func TestSubtitles_Rewrap(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Text: "Short\nlines"},
+		{Index: 2, Text: "Far too long to fit in anything"},
+	}}
+
+	rewrapped, unfit := subtitles.Rewrap(12, 2)
+
+	assert.Equal(t, "Short lines", rewrapped.Items[0].Text)
+	assert.Equal(t, "Far too long to fit in anything", rewrapped.Items[1].Text)
+	assert.Equal(t, []int{1}, unfit)
+	assert.Equal(t, "Short\nlines", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+}
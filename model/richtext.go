@@ -0,0 +1,444 @@
+package model
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Alignment is the position of a cue on screen, numbered like the numeric
+// keypad as in the "{\anN}" tag. The zero value means no explicit position,
+// which players render as AlignBottomCenter.
+type Alignment int
+
+const (
+	AlignDefault Alignment = iota
+	AlignBottomLeft
+	AlignBottomCenter
+	AlignBottomRight
+	AlignMiddleLeft
+	AlignMiddleCenter
+	AlignMiddleRight
+	AlignTopLeft
+	AlignTopCenter
+	AlignTopRight
+)
+
+// Span is a run of text sharing the same formatting. Color, Face and Size
+// hold the attributes of the enclosing <font> tags as written, and are empty
+// or zero when unset.
+type Span struct {
+	Text      string
+	Italic    bool
+	Bold      bool
+	Underline bool
+	Color     string
+	Face      string
+	Size      int
+}
+
+// sameStyle reports whether s and o have the same formatting.
+func (s Span) sameStyle(o Span) bool {
+	s.Text, o.Text = "", ""
+	return s == o
+}
+
+// RichText is the parsed form of the markup of a cue text.
+type RichText struct {
+	Alignment Alignment
+	Spans     []Span
+}
+
+// element is a formatting tag opened in the markup.
+type element struct {
+	name  string
+	style Span
+}
+
+var attributePattern = regexp.MustCompile(`(?i)([a-z]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"']+))`)
+
+// ParseRichText parses the <i>, <b>, <u> and <font> tags and the "{\anN}"
+// position of a cue text. It never fails: unclosed tags run to the end of the
+// text, a closing tag ends the latest matching opening tag even if other tags
+// were opened after it, and closing tags without a matching opening tag are
+// ignored. Unknown tags are kept as text, while override blocks such as
+// "{\pos(10,10)}" are dropped.
+func ParseRichText(text string) RichText {
+	var t RichText
+	var open []element
+	var b strings.Builder
+
+	flush := func() {
+		if b.Len() > 0 {
+			t.Spans = appendSpan(t.Spans, currentStyle(open, b.String()))
+			b.Reset()
+		}
+	}
+
+	for len(text) > 0 {
+		n := tagLength(text)
+		if n == 0 {
+			b.WriteByte(text[0])
+			text = text[1:]
+			continue
+		}
+
+		flush()
+		if tag := text[:n]; tag[0] == '<' {
+			name, closing, style, _ := parseTag(tag[1 : n-1])
+			if closing {
+				open = closeElement(open, name)
+			} else {
+				open = append(open, element{name: name, style: style})
+			}
+		} else {
+			for _, override := range strings.Split(tag[2:n-1], `\`) {
+				override = strings.TrimSpace(override)
+				if !strings.HasPrefix(override, "an") || t.Alignment != AlignDefault {
+					continue
+				}
+				if a, err := strconv.Atoi(override[2:]); err == nil && a >= 1 && a <= 9 {
+					t.Alignment = Alignment(a)
+				}
+			}
+		}
+		text = text[n:]
+	}
+	flush()
+
+	return t
+}
+
+// tagLength returns the length of the formatting tag or override block at
+// the start of text, or 0 if text does not start with one.
+func tagLength(text string) int {
+	switch {
+	case strings.HasPrefix(text, "<"):
+		end := strings.IndexAny(text[1:], "<>")
+		if end < 0 || text[end+1] != '>' {
+			return 0
+		}
+		if _, _, _, ok := parseTag(text[1 : end+1]); ok {
+			return end + 2
+		}
+	case strings.HasPrefix(text, `{\`):
+		if end := strings.IndexByte(text, '}'); end >= 0 {
+			return end + 1
+		}
+	}
+	return 0
+}
+
+// parseTag parses the content of a tag between "<" and ">". It reports false
+// for tags other than i, b, u and font, and when the name does not directly
+// follow "<" or "</", as in "a < b > c".
+func parseTag(tag string) (name string, closing bool, style Span, ok bool) {
+	if strings.HasPrefix(tag, "/") {
+		closing = true
+		tag = tag[1:]
+	}
+	tag = strings.TrimRightFunc(tag, unicode.IsSpace)
+
+	name = strings.ToLower(tag)
+	attributes := ""
+	if i := strings.IndexAny(tag, " \t\n"); i >= 0 {
+		name, attributes = strings.ToLower(tag[:i]), tag[i:]
+	}
+
+	switch name {
+	case "i":
+		style.Italic = true
+	case "b":
+		style.Bold = true
+	case "u":
+		style.Underline = true
+	case "font":
+		for _, m := range attributePattern.FindAllStringSubmatch(attributes, -1) {
+			value := m[2] + m[3] + m[4]
+			switch strings.ToLower(m[1]) {
+			case "color":
+				style.Color = value
+			case "face":
+				style.Face = value
+			case "size":
+				style.Size, _ = strconv.Atoi(value)
+			}
+		}
+	default:
+		return "", false, Span{}, false
+	}
+
+	return name, closing, style, true
+}
+
+// closeElement removes the latest element with the given name from open.
+func closeElement(open []element, name string) []element {
+	for i := len(open) - 1; i >= 0; i-- {
+		if open[i].name == name {
+			return append(open[:i:i], open[i+1:]...)
+		}
+	}
+	return open
+}
+
+// currentStyle returns a Span of text with the formatting of the open
+// elements, the innermost font attributes taking precedence.
+func currentStyle(open []element, text string) Span {
+	s := Span{Text: text}
+	for _, e := range open {
+		s.Italic = s.Italic || e.style.Italic
+		s.Bold = s.Bold || e.style.Bold
+		s.Underline = s.Underline || e.style.Underline
+		if e.style.Color != "" {
+			s.Color = e.style.Color
+		}
+		if e.style.Face != "" {
+			s.Face = e.style.Face
+		}
+		if e.style.Size != 0 {
+			s.Size = e.style.Size
+		}
+	}
+	return s
+}
+
+// appendSpan appends s to spans, merging it with the last span when they
+// have the same formatting. Empty spans are dropped.
+func appendSpan(spans []Span, s Span) []Span {
+	if s.Text == "" {
+		return spans
+	}
+	if n := len(spans); n > 0 && spans[n-1].sameStyle(s) {
+		spans[n-1].Text += s.Text
+		return spans
+	}
+	return append(spans, s)
+}
+
+// slice returns the part of t between the byte offsets from and to of its
+// plain text, without leading and trailing white space.
+func (t RichText) slice(from, to int) RichText {
+	result := RichText{Alignment: t.Alignment}
+	offset := 0
+	for _, s := range t.Spans {
+		start, end := offset, offset+len(s.Text)
+		offset = end
+		if end <= from || start >= to {
+			continue
+		}
+		if start < from {
+			s.Text = s.Text[from-start:]
+			start = from
+		}
+		if end > to {
+			s.Text = s.Text[:to-start]
+		}
+		result.Spans = appendSpan(result.Spans, s)
+	}
+
+	for len(result.Spans) > 0 {
+		first := &result.Spans[0]
+		first.Text = strings.TrimLeftFunc(first.Text, unicode.IsSpace)
+		if first.Text != "" {
+			break
+		}
+		result.Spans = result.Spans[1:]
+	}
+	for len(result.Spans) > 0 {
+		last := &result.Spans[len(result.Spans)-1]
+		last.Text = strings.TrimRightFunc(last.Text, unicode.IsSpace)
+		if last.Text != "" {
+			break
+		}
+		result.Spans = result.Spans[:len(result.Spans)-1]
+	}
+
+	return result
+}
+
+// without returns t without the parts of its plain text in ranges, which
+// must be sorted and must not overlap.
+func (t RichText) without(ranges []textRange) RichText {
+	result := RichText{Alignment: t.Alignment}
+	offset, k := 0, 0
+	for _, s := range t.Spans {
+		var b strings.Builder
+		for i := 0; i < len(s.Text); i++ {
+			o := offset + i
+			for k < len(ranges) && ranges[k].end <= o {
+				k++
+			}
+			if k < len(ranges) && ranges[k].start <= o {
+				continue
+			}
+			b.WriteByte(s.Text[i])
+		}
+		offset += len(s.Text)
+
+		s.Text = b.String()
+		result.Spans = appendSpan(result.Spans, s)
+	}
+	return result
+}
+
+// replace returns t with the part of its plain text in r replaced by text,
+// which takes the formatting of the first character of r, or of the
+// character following r when it is empty.
+func (t RichText) replace(r textRange, text string) RichText {
+	result := RichText{Alignment: t.Alignment}
+	offset := 0
+	for _, s := range t.Spans {
+		var b strings.Builder
+		for i := 0; i < len(s.Text); i++ {
+			o := offset + i
+			if o == r.start {
+				b.WriteString(text)
+			}
+			if o < r.start || o >= r.end {
+				b.WriteByte(s.Text[i])
+			}
+		}
+		offset += len(s.Text)
+
+		s.Text = b.String()
+		result.Spans = appendSpan(result.Spans, s)
+	}
+
+	if n := len(t.Spans); r.start >= offset && n > 0 {
+		s := t.Spans[n-1]
+		s.Text = text
+		result.Spans = appendSpan(result.Spans, s)
+	}
+	return result
+}
+
+// PlainText returns the text without any formatting.
+func (t RichText) PlainText() string {
+	var b strings.Builder
+	for _, s := range t.Spans {
+		b.WriteString(s.Text)
+	}
+	return b.String()
+}
+
+// String returns the text as markup, prefixed with "{\anN}" when the
+// alignment is set. Tags are opened only when their formatting starts, and
+// always closed in the reverse order they were opened.
+func (t RichText) String() string {
+	var b strings.Builder
+	if t.Alignment != AlignDefault {
+		b.WriteString(`{\an` + strconv.Itoa(int(t.Alignment)) + "}")
+	}
+
+	var open []string
+	var spans []Span
+	for _, s := range t.Spans {
+		spans = appendSpan(spans, s)
+	}
+	for _, s := range spans {
+		wanted := s.tags()
+
+		// Keep the longest prefix of open tags that is still wanted, close
+		// the rest, then open the missing ones.
+		keep := 0
+		for keep < len(open) && keep < len(wanted) && open[keep] == wanted[keep] {
+			keep++
+		}
+		for i := len(open) - 1; i >= keep; i-- {
+			b.WriteString(closingTag(open[i]))
+		}
+		open = open[:keep]
+		for _, tag := range wanted[keep:] {
+			b.WriteString(tag)
+			open = append(open, tag)
+		}
+
+		b.WriteString(s.Text)
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString(closingTag(open[i]))
+	}
+
+	return b.String()
+}
+
+// tags returns the opening tags for the formatting of s.
+func (s Span) tags() []string {
+	var tags []string
+	if s.Italic {
+		tags = append(tags, "<i>")
+	}
+	if s.Bold {
+		tags = append(tags, "<b>")
+	}
+	if s.Underline {
+		tags = append(tags, "<u>")
+	}
+	if s.Color != "" || s.Face != "" || s.Size != 0 {
+		tag := "<font"
+		if s.Color != "" {
+			tag += ` color="` + s.Color + `"`
+		}
+		if s.Face != "" {
+			tag += ` face="` + s.Face + `"`
+		}
+		if s.Size != 0 {
+			tag += ` size="` + strconv.Itoa(s.Size) + `"`
+		}
+		tags = append(tags, tag+">")
+	}
+	return tags
+}
+
+// closingTag returns the closing tag matching an opening tag.
+func closingTag(tag string) string {
+	name := strings.TrimPrefix(tag, "<")
+	if i := strings.IndexAny(name, " >"); i >= 0 {
+		name = name[:i]
+	}
+	return "</" + name + ">"
+}
+
+// MapText returns a new Cue whose text between formatting tags is replaced
+// by the result of f. The tags and override blocks are kept as they are, and
+// f is called once for each run of text between them.
+func (c Cue) MapText(f func(text string) string) Cue {
+	var b, run strings.Builder
+	flush := func() {
+		if run.Len() > 0 {
+			b.WriteString(f(run.String()))
+			run.Reset()
+		}
+	}
+
+	for text := c.Text; len(text) > 0; {
+		if n := tagLength(text); n > 0 {
+			flush()
+			b.WriteString(text[:n])
+			text = text[n:]
+			continue
+		}
+		run.WriteByte(text[0])
+		text = text[1:]
+	}
+	flush()
+
+	c.Text = b.String()
+	return c
+}
+
+// Spans returns the formatted runs of the Cue text.
+func (c Cue) Spans() []Span {
+	return ParseRichText(c.Text).Spans
+}
+
+// Alignment returns the position set by a "{\anN}" tag in the Cue text.
+func (c Cue) Alignment() Alignment {
+	return ParseRichText(c.Text).Alignment
+}
+
+// PlainText returns the Cue text without formatting tags.
+func (c Cue) PlainText() string {
+	return ParseRichText(c.Text).PlainText()
+}
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRichText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected RichText
+	}{
+		{
+			name:     "plain",
+			text:     "Hello,\nWorld!",
+			expected: RichText{Spans: []Span{{Text: "Hello,\nWorld!"}}},
+		},
+		{
+			name: "nested",
+			text: "<i>Hello, <b>World</b></i>!",
+			expected: RichText{Spans: []Span{
+				{Text: "Hello, ", Italic: true},
+				{Text: "World", Italic: true, Bold: true},
+				{Text: "!"},
+			}},
+		},
+		{
+			name: "font attributes",
+			text: `<font color="#FF0000" face='Arial' size=20>Red</font> <FONT COLOR=blue>blue</FONT>`,
+			expected: RichText{Spans: []Span{
+				{Text: "Red", Color: "#FF0000", Face: "Arial", Size: 20},
+				{Text: " "},
+				{Text: "blue", Color: "blue"},
+			}},
+		},
+		{
+			name: "inner font overrides outer font",
+			text: `<font color="red" face="Arial"><font color="blue">blue</font> red</font>`,
+			expected: RichText{Spans: []Span{
+				{Text: "blue", Color: "blue", Face: "Arial"},
+				{Text: " red", Color: "red", Face: "Arial"},
+			}},
+		},
+		{
+			name: "alignment",
+			text: `{\an8}<u>Top</u>`,
+			expected: RichText{Alignment: AlignTopCenter, Spans: []Span{
+				{Text: "Top", Underline: true},
+			}},
+		},
+		{
+			name: "first alignment wins and other overrides are dropped",
+			text: `{\pos(10,10)\an7}Left{\an9}`,
+			expected: RichText{Alignment: AlignTopLeft, Spans: []Span{
+				{Text: "Left"},
+			}},
+		},
+		{
+			name: "unclosed tag",
+			text: "<i>Hello\nWorld",
+			expected: RichText{Spans: []Span{
+				{Text: "Hello\nWorld", Italic: true},
+			}},
+		},
+		{
+			name: "mis-nested tags",
+			text: "<b>bold <i>both</b> italic</i> plain",
+			expected: RichText{Spans: []Span{
+				{Text: "bold ", Bold: true},
+				{Text: "both", Bold: true, Italic: true},
+				{Text: " italic", Italic: true},
+				{Text: " plain"},
+			}},
+		},
+		{
+			name: "stray closing tag",
+			text: "Hello</i> <i>World</i></b>",
+			expected: RichText{Spans: []Span{
+				{Text: "Hello "},
+				{Text: "World", Italic: true},
+			}},
+		},
+		{
+			name:     "unknown tags and brackets are text",
+			text:     "1 < 2 <3 <x>y</x> {braces}",
+			expected: RichText{Spans: []Span{{Text: "1 < 2 <3 <x>y</x> {braces}"}}},
+		},
+		{
+			name:     "spaced brackets are text",
+			text:     "a < b > c </ i > d",
+			expected: RichText{Spans: []Span{{Text: "a < b > c </ i > d"}}},
+		},
+		{
+			name: "space before the closing bracket",
+			text: "<i >Hello</i >",
+			expected: RichText{Spans: []Span{
+				{Text: "Hello", Italic: true},
+			}},
+		},
+		{
+			name:     "empty",
+			text:     "",
+			expected: RichText{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseRichText(tt.text))
+		})
+	}
+}
+
+func TestRichText_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     RichText
+		expected string
+	}{
+		{
+			name: "nested",
+			text: RichText{Spans: []Span{
+				{Text: "Hello, ", Italic: true},
+				{Text: "World", Italic: true, Bold: true},
+				{Text: "!"},
+			}},
+			expected: "<i>Hello, <b>World</b></i>!",
+		},
+		{
+			name:     "mis-nested input is written well nested",
+			text:     ParseRichText("<b>bold <i>both</b> italic</i>"),
+			expected: "<b>bold </b><i><b>both</b> italic</i>",
+		},
+		{
+			name: "font and alignment",
+			text: RichText{Alignment: AlignTopRight, Spans: []Span{
+				{Text: "Big", Color: "#00FF00", Face: "Arial", Size: 20},
+			}},
+			expected: `{\an9}<font color="#00FF00" face="Arial" size="20">Big</font>`,
+		},
+		{
+			name: "same formatting is merged",
+			text: RichText{Spans: []Span{
+				{Text: "One ", Underline: true},
+				{Text: ""},
+				{Text: "two", Underline: true},
+			}},
+			expected: "<u>One two</u>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.text.String())
+			assert.Equal(t, tt.text.PlainText(), ParseRichText(tt.expected).PlainText(), "Expected the markup to parse back to the same text")
+		})
+	}
+}
+
+func TestCue_RichText(t *testing.T) {
+	cue := Cue{Text: `{\an8}<i>Hello</i>, <font color="red">World</font>`}
+
+	assert.Equal(t, AlignTopCenter, cue.Alignment())
+	assert.Equal(t, "Hello, World", cue.PlainText())
+	assert.Equal(t, []Span{
+		{Text: "Hello", Italic: true},
+		{Text: ", "},
+		{Text: "World", Color: "red"},
+	}, cue.Spans())
+
+	assert.Equal(t, AlignDefault, Cue{Text: "Plain"}.Alignment())
+}
+
+func TestCue_MapText(t *testing.T) {
+	var runs []string
+	cue := Cue{Text: `{\an8}<I>Hello</I>, <font color="red">World</font> <3`}
+	mapped := cue.MapText(func(text string) string {
+		runs = append(runs, text)
+		return strings.ToUpper(text)
+	})
+
+	assert.Equal(t, `{\an8}<I>HELLO</I>, <font color="red">WORLD</font> <3`, mapped.Text)
+	assert.Equal(t, []string{"Hello", ", ", "World", " <3"}, runs)
+	assert.Equal(t, `{\an8}<I>Hello</I>, <font color="red">World</font> <3`, cue.Text)
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrInvalidRange is returned when a time range ends before it starts.
+	ErrInvalidRange = errors.New("range ends before it starts")
+	// ErrNegativeTime is returned when a cue would start before 0.
+	ErrNegativeTime = errors.New("cue would start before 0")
+)
+
+// NegativePolicy is how cues that would start before 0 are handled.
+type NegativePolicy int
+
+const (
+	// ClampNegative moves the start of such cues to 0.
+	ClampNegative NegativePolicy = iota
+	// RefuseNegative fails with ErrNegativeTime.
+	RefuseNegative
+)
+
+// SliceOptions configures Slice.
+type SliceOptions struct {
+	// KeepBoundaries keeps the cues overlapping the window whole instead of
+	// clipping them to the window.
+	KeepBoundaries bool
+	// Rebase shifts the cues so that the start of the window becomes 0.
+	Rebase bool
+	// Negative selects how rebased cues starting before the window, which
+	// can only be kept with KeepBoundaries, are handled.
+	Negative NegativePolicy
+}
+
+// Slice returns a new Subtitles with the cues displayed between from and to,
+// renumbered from 1. Cues are clipped to the window unless
+// opts.KeepBoundaries is set, and rebased to the start of the window if
+// opts.Rebase is set.
+func (s Subtitles) Slice(from, to Duration, opts SliceOptions) (Subtitles, error) {
+	if to < from {
+		return Subtitles{}, ErrInvalidRange
+	}
+
+	var items []Cue
+	for _, cue := range s.Items {
+		starts := cue.Start >= from && cue.Start < to
+		overlaps := cue.Start < to && cue.End > from
+		if !starts && !overlaps {
+			continue
+		}
+
+		if !opts.KeepBoundaries {
+			if cue.Start < from {
+				cue.Start = from
+			}
+			if cue.End > to {
+				cue.End = to
+			}
+		}
+
+		if opts.Rebase {
+			cue = cue.Shift(-time.Duration(from))
+			if cue.Start < 0 {
+				if opts.Negative == RefuseNegative {
+					return Subtitles{}, fmt.Errorf("cue %d: %w", cue.Index, ErrNegativeTime)
+				}
+				cue.Start = 0
+			}
+		}
+
+		items = append(items, cue)
+	}
+	renumber(items)
+
+	return Subtitles{Items: items}, nil
+}
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubtitles_Slice(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "Before"},
+		{Index: 2, Start: Duration(9 * time.Second), End: Duration(11 * time.Second), Text: "Entering"},
+		{Index: 3, Start: Duration(12 * time.Second), End: Duration(13 * time.Second), Text: "Inside"},
+		{Index: 4, Start: Duration(14 * time.Second), End: Duration(16 * time.Second), Text: "Leaving"},
+		{Index: 5, Start: Duration(20 * time.Second), End: Duration(21 * time.Second), Text: "After"},
+	}}
+	from, to := Duration(10*time.Second), Duration(15*time.Second)
+
+	tests := []struct {
+		name     string
+		opts     SliceOptions
+		expected []Cue
+	}{
+		{
+			name: "clip",
+			opts: SliceOptions{},
+			expected: []Cue{
+				{Index: 1, Start: Duration(10 * time.Second), End: Duration(11 * time.Second), Text: "Entering"},
+				{Index: 2, Start: Duration(12 * time.Second), End: Duration(13 * time.Second), Text: "Inside"},
+				{Index: 3, Start: Duration(14 * time.Second), End: Duration(15 * time.Second), Text: "Leaving"},
+			},
+		},
+		{
+			name: "clip and rebase",
+			opts: SliceOptions{Rebase: true},
+			expected: []Cue{
+				{Index: 1, Start: Duration(0), End: Duration(1 * time.Second), Text: "Entering"},
+				{Index: 2, Start: Duration(2 * time.Second), End: Duration(3 * time.Second), Text: "Inside"},
+				{Index: 3, Start: Duration(4 * time.Second), End: Duration(5 * time.Second), Text: "Leaving"},
+			},
+		},
+		{
+			name: "keep boundaries",
+			opts: SliceOptions{KeepBoundaries: true},
+			expected: []Cue{
+				{Index: 1, Start: Duration(9 * time.Second), End: Duration(11 * time.Second), Text: "Entering"},
+				{Index: 2, Start: Duration(12 * time.Second), End: Duration(13 * time.Second), Text: "Inside"},
+				{Index: 3, Start: Duration(14 * time.Second), End: Duration(16 * time.Second), Text: "Leaving"},
+			},
+		},
+		{
+			name: "keep boundaries, rebase and clamp",
+			opts: SliceOptions{KeepBoundaries: true, Rebase: true},
+			expected: []Cue{
+				{Index: 1, Start: Duration(0), End: Duration(1 * time.Second), Text: "Entering"},
+				{Index: 2, Start: Duration(2 * time.Second), End: Duration(3 * time.Second), Text: "Inside"},
+				{Index: 3, Start: Duration(4 * time.Second), End: Duration(6 * time.Second), Text: "Leaving"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sliced, err := subtitles.Slice(from, to, tt.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, sliced.Items)
+		})
+	}
+
+	assert.Equal(t, Duration(9*time.Second), subtitles.Items[1].Start, "Expected original subtitles to be unchanged")
+}
+
+func TestSubtitles_SliceErrors(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "Before"},
+		{Index: 2, Start: Duration(9 * time.Second), End: Duration(11 * time.Second), Text: "Entering"},
+		{Index: 3, Start: Duration(12 * time.Second), End: Duration(13 * time.Second), Text: "Inside"},
+		{Index: 4, Start: Duration(14 * time.Second), End: Duration(16 * time.Second), Text: "Leaving"},
+		{Index: 5, Start: Duration(20 * time.Second), End: Duration(21 * time.Second), Text: "After"},
+	}}
+
+	_, err := subtitles.Slice(Duration(10*time.Second), Duration(15*time.Second), SliceOptions{KeepBoundaries: true, Rebase: true, Negative: RefuseNegative})
+	assert.ErrorIs(t, err, ErrNegativeTime)
+	assert.EqualError(t, err, "cue 2: cue would start before 0")
+
+	_, err = subtitles.Slice(Duration(15*time.Second), Duration(10*time.Second), SliceOptions{})
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	sliced, err := subtitles.Slice(Duration(30*time.Second), Duration(40*time.Second), SliceOptions{Rebase: true, Negative: RefuseNegative})
+	assert.NoError(t, err)
+	assert.Empty(t, sliced.Items)
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// StraddlePolicy is how cues displayed across a cut are split.
+type StraddlePolicy int
+
+const (
+	// StraddleAssign puts a cue only in the part in which it starts.
+	StraddleAssign StraddlePolicy = iota
+	// StraddleClip puts a cue in every part it is displayed in, clipped to
+	// the part.
+	StraddleClip
+	// StraddleDuplicate puts a cue unchanged in every part it is displayed
+	// in. A rebased cue starting before its part starts at 0.
+	StraddleDuplicate
+)
+
+// SplitOptions configures SplitAt and SplitByRanges.
+type SplitOptions struct {
+	Straddle StraddlePolicy
+	// KeepTimes keeps the original times of the cues instead of rebasing
+	// each part so that it starts at 0.
+	KeepTimes bool
+}
+
+// TimeRange is the span of time from Start, included, to End, excluded.
+type TimeRange struct {
+	Start Duration
+	End   Duration
+}
+
+// forever is the end of the last part of SplitAt.
+const forever = Duration(math.MaxInt64)
+
+// SplitAt splits the Subtitles at t into two new Subtitles, each renumbered
+// from 1. The second part is rebased so that t becomes 0, unless
+// opts.KeepTimes is set.
+func (s Subtitles) SplitAt(t Duration, opts SplitOptions) (Subtitles, Subtitles) {
+	parts := s.SplitByRanges([]TimeRange{{Start: 0, End: t}, {Start: t, End: forever}}, opts)
+	return parts[0], parts[1]
+}
+
+// SplitByRanges returns a new Subtitles for each range, with the cues
+// displayed in the range renumbered from 1. Each part is rebased so that the
+// start of its range becomes 0, unless opts.KeepTimes is set.
+func (s Subtitles) SplitByRanges(ranges []TimeRange, opts SplitOptions) []Subtitles {
+	parts := make([]Subtitles, len(ranges))
+	for i, r := range ranges {
+		var items []Cue
+		for _, cue := range s.Items {
+			starts := cue.Start >= r.Start && cue.Start < r.End
+			overlaps := cue.Start < r.End && cue.End > r.Start
+
+			switch {
+			case starts && opts.Straddle == StraddleAssign:
+			case (starts || overlaps) && opts.Straddle == StraddleDuplicate:
+			case (starts || overlaps) && opts.Straddle == StraddleClip:
+				if cue.Start < r.Start {
+					cue.Start = r.Start
+				}
+				if cue.End > r.End {
+					cue.End = r.End
+				}
+			default:
+				continue
+			}
+
+			if !opts.KeepTimes {
+				cue = cue.Shift(-time.Duration(r.Start))
+				if cue.Start < 0 {
+					cue.Start = 0
+				}
+			}
+			items = append(items, cue)
+		}
+
+		renumber(items)
+		parts[i] = Subtitles{Items: items}
+	}
+	return parts
+}
+
+// SplitEvery splits the Subtitles into new Subtitles of n cues each, the last
+// one holding the remaining cues. Each part is renumbered from 1 and keeps
+// the original times; use Shift to rebase it. A non-positive n returns a
+// single part.
+func (s Subtitles) SplitEvery(n int) []Subtitles {
+	if n <= 0 {
+		n = len(s.Items)
+	}
+
+	var parts []Subtitles
+	for start := 0; start < len(s.Items); start += n {
+		end := start + n
+		if end > len(s.Items) {
+			end = len(s.Items)
+		}
+
+		items := make([]Cue, end-start)
+		copy(items, s.Items[start:end])
+		renumber(items)
+		parts = append(parts, Subtitles{Items: items})
+	}
+	return parts
+}
+
+// Concat returns a new Subtitles with the cues of parts in order, each part
+// shifted by the offset at the same position in offsets, then renumbered.
+// Parts without offset are not shifted.
+func Concat(parts []Subtitles, offsets []time.Duration) Subtitles {
+	var items []Cue
+	for i, part := range parts {
+		if i < len(offsets) {
+			part = part.Shift(offsets[i])
+		}
+		items = append(items, part.Items...)
+	}
+	renumber(items)
+
+	return Subtitles{Items: items}
+}
@@ -0,0 +1,139 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubtitles_SplitAt(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "First"},
+		{Index: 2, Start: Duration(9 * time.Second), End: Duration(12 * time.Second), Text: "Straddling"},
+		{Index: 3, Start: Duration(15 * time.Second), End: Duration(16 * time.Second), Text: "Third"},
+	}}
+	cut := Duration(10 * time.Second)
+
+	tests := []struct {
+		name   string
+		opts   SplitOptions
+		first  []Cue
+		second []Cue
+	}{
+		{
+			name: "assign",
+			opts: SplitOptions{Straddle: StraddleAssign},
+			first: []Cue{
+				{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "First"},
+				{Index: 2, Start: Duration(9 * time.Second), End: Duration(12 * time.Second), Text: "Straddling"},
+			},
+			second: []Cue{
+				{Index: 1, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "Third"},
+			},
+		},
+		{
+			name: "clip",
+			opts: SplitOptions{Straddle: StraddleClip},
+			first: []Cue{
+				{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "First"},
+				{Index: 2, Start: Duration(9 * time.Second), End: Duration(10 * time.Second), Text: "Straddling"},
+			},
+			second: []Cue{
+				{Index: 1, Start: Duration(0), End: Duration(2 * time.Second), Text: "Straddling"},
+				{Index: 2, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "Third"},
+			},
+		},
+		{
+			name: "duplicate",
+			opts: SplitOptions{Straddle: StraddleDuplicate},
+			first: []Cue{
+				{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "First"},
+				{Index: 2, Start: Duration(9 * time.Second), End: Duration(12 * time.Second), Text: "Straddling"},
+			},
+			second: []Cue{
+				{Index: 1, Start: Duration(0), End: Duration(2 * time.Second), Text: "Straddling"},
+				{Index: 2, Start: Duration(5 * time.Second), End: Duration(6 * time.Second), Text: "Third"},
+			},
+		},
+		{
+			name: "keep times",
+			opts: SplitOptions{Straddle: StraddleDuplicate, KeepTimes: true},
+			first: []Cue{
+				{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "First"},
+				{Index: 2, Start: Duration(9 * time.Second), End: Duration(12 * time.Second), Text: "Straddling"},
+			},
+			second: []Cue{
+				{Index: 1, Start: Duration(9 * time.Second), End: Duration(12 * time.Second), Text: "Straddling"},
+				{Index: 2, Start: Duration(15 * time.Second), End: Duration(16 * time.Second), Text: "Third"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, second := subtitles.SplitAt(cut, tt.opts)
+			assert.Equal(t, tt.first, first.Items)
+			assert.Equal(t, tt.second, second.Items)
+		})
+	}
+
+	assert.Equal(t, 3, subtitles.Items[2].Index, "Expected original subtitles to be unchanged")
+}
+
+func TestSubtitles_SplitByRanges(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "First"},
+		{Index: 2, Start: Duration(9 * time.Second), End: Duration(12 * time.Second), Text: "Straddling"},
+		{Index: 3, Start: Duration(15 * time.Second), End: Duration(16 * time.Second), Text: "Third"},
+	}}
+
+	parts := subtitles.SplitByRanges([]TimeRange{
+		{Start: Duration(0), End: Duration(5 * time.Second)},
+		{Start: Duration(14 * time.Second), End: Duration(20 * time.Second)},
+		{Start: Duration(30 * time.Second), End: Duration(40 * time.Second)},
+	}, SplitOptions{})
+
+	assert.Len(t, parts, 3)
+	assert.Equal(t, []Cue{{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "First"}}, parts[0].Items)
+	assert.Equal(t, []Cue{{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "Third"}}, parts[1].Items)
+	assert.Empty(t, parts[2].Items)
+}
+
+func TestSubtitles_SplitEvery(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "First"},
+		{Index: 2, Start: Duration(9 * time.Second), End: Duration(12 * time.Second), Text: "Straddling"},
+		{Index: 3, Start: Duration(15 * time.Second), End: Duration(16 * time.Second), Text: "Third"},
+	}}
+
+	parts := subtitles.SplitEvery(2)
+	assert.Len(t, parts, 2)
+	assert.Equal(t, []int{1, 2}, []int{parts[0].Items[0].Index, parts[0].Items[1].Index})
+	assert.Equal(t, Cue{Index: 1, Start: Duration(15 * time.Second), End: Duration(16 * time.Second), Text: "Third"}, parts[1].Items[0])
+
+	assert.Len(t, subtitles.SplitEvery(0), 1)
+	assert.Len(t, subtitles.SplitEvery(5), 1)
+	assert.Empty(t, Subtitles{}.SplitEvery(2))
+
+	parts[0].Items[0].Text = "Changed"
+	assert.Equal(t, "First", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+}
+
+func TestConcat(t *testing.T) {
+	subtitles := Subtitles{Items: []Cue{
+		{Index: 1, Start: Duration(1 * time.Second), End: Duration(2 * time.Second), Text: "First"},
+		{Index: 2, Start: Duration(9 * time.Second), End: Duration(12 * time.Second), Text: "Straddling"},
+		{Index: 3, Start: Duration(15 * time.Second), End: Duration(16 * time.Second), Text: "Third"},
+	}}
+	first, second := subtitles.SplitAt(Duration(10*time.Second), SplitOptions{})
+
+	joined := Concat([]Subtitles{first, second}, []time.Duration{0, 10 * time.Second})
+	assert.Equal(t, subtitles.Items, joined.Items)
+
+	joined = Concat([]Subtitles{first, second}, nil)
+	assert.Equal(t, Duration(5*time.Second), joined.Items[2].Start)
+	assert.Equal(t, 3, joined.Items[2].Index)
+
+	assert.Empty(t, Concat(nil, nil).Items)
+}
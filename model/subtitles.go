@@ -6,6 +6,11 @@ import (
 	"time"
 )
 
+// Subtitles is a track of cues.
+//
+// The methods of Subtitles never modify the receiver. Those returning a
+// Subtitles without error return a new slice of cues, so that changing the
+// result never changes the original, and the other way round.
 type Subtitles struct {
 	Items []Cue
 }
@@ -19,19 +24,28 @@ func (s Subtitles) Shift(offset time.Duration) Subtitles {
 	return Subtitles{Items: shiftedCues}
 }
 
+// MapText returns a new Subtitles with the text of every Cue between
+// formatting tags replaced by the result of f, as done by Cue.MapText.
+func (s Subtitles) MapText(f func(text string) string) Subtitles {
+	items := make([]Cue, len(s.Items))
+	for i, cue := range s.Items {
+		items[i] = cue.MapText(f)
+	}
+	return Subtitles{Items: items}
+}
+
 // RemoveAt removes the Cue at the specified index and returns a new Subtitles.
 func (s Subtitles) RemoveAt(index int) Subtitles {
 	if index < 0 || index >= len(s.Items) {
-		return s
+		return Subtitles{Items: s.clone()}
 	}
 
-	s.Items = append(s.Items[:index], s.Items[index+1:]...)
-
-	for i := range s.Items {
-		s.Items[i].Index = i + 1
-	}
+	items := make([]Cue, 0, len(s.Items)-1)
+	items = append(items, s.Items[:index]...)
+	items = append(items, s.Items[index+1:]...)
+	renumber(items)
 
-	return s
+	return Subtitles{Items: items}
 }
 
 // RemoveAtIndices removes the Cues at the specified indices and returns a new Subtitles.
@@ -50,14 +64,27 @@ func (s Subtitles) RemoveAtIndices(indices []int) Subtitles {
 		}
 	}
 
-	for i := range newItems {
-		newItems[i].Index = i + 1
-	}
+	renumber(newItems)
 
 	return Subtitles{Items: newItems}
 }
 
-// Write writes the Subtitles in SRT format to the given io.Writer.
+// clone returns a copy of the cues of s.
+func (s Subtitles) clone() []Cue {
+	items := make([]Cue, len(s.Items))
+	copy(items, s.Items)
+	return items
+}
+
+// renumber sets the Index of each cue to its 1-based position.
+func renumber(items []Cue) {
+	for i := range items {
+		items[i].Index = i + 1
+	}
+}
+
+// Write writes the Subtitles in SRT format to the given io.Writer. Cue
+// settings and identifiers, which SRT cannot represent, are not written.
 func (s Subtitles) Write(writer io.Writer) (int, error) {
 	var b strings.Builder
 
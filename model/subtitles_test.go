@@ -25,6 +25,21 @@ func TestSubtitles_Shift(t *testing.T) {
 	assert.Equal(t, Duration(8*time.Second), shifted.Items[1].End, "Expected second item's end to be 8 seconds, got %v", shifted.Items[1].End)
 }
 
+func TestSubtitles_MapText(t *testing.T) {
+	subtitles := Subtitles{
+		Items: []Cue{
+			{Index: 1, Start: Duration(1 * time.Second), End: Duration(3 * time.Second), Text: "<i>first</i> line"},
+			{Index: 2, Start: Duration(4 * time.Second), End: Duration(6 * time.Second), Text: `{\an8}second <3`},
+		},
+	}
+
+	mapped := subtitles.MapText(strings.ToUpper)
+
+	assert.Equal(t, "<i>FIRST</i> LINE", mapped.Items[0].Text)
+	assert.Equal(t, `{\an8}SECOND <3`, mapped.Items[1].Text)
+	assert.Equal(t, "<i>first</i> line", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
+}
+
 func TestSubtitles_RemoveAt(t *testing.T) {
 	subtitles := Subtitles{
 		Items: []Cue{
@@ -41,6 +56,13 @@ func TestSubtitles_RemoveAt(t *testing.T) {
 	assert.Equal(t, "First", updated.Items[0].Text, "Expected first item's text to be 'First', got '%s'", updated.Items[0].Text)
 	assert.Equal(t, 2, updated.Items[1].Index, "Expected second item's index to be 2, got %d", updated.Items[1].Index)
 	assert.Equal(t, "Third", updated.Items[1].Text, "Expected second item's text to be 'Third', got '%s'", updated.Items[1].Text)
+
+	assert.Equal(t, []string{"First", "Second", "Third"}, []string{subtitles.Items[0].Text, subtitles.Items[1].Text, subtitles.Items[2].Text}, "Expected original subtitles to be unchanged")
+	assert.Equal(t, 3, subtitles.Items[2].Index, "Expected original subtitles to be unchanged")
+
+	unchanged := subtitles.RemoveAt(5)
+	unchanged.Items[0].Text = "Changed"
+	assert.Equal(t, "First", subtitles.Items[0].Text, "Expected original subtitles to be unchanged")
 }
 
 func TestSubtitles_RemoveAtIndices(t *testing.T) {
@@ -74,7 +96,7 @@ func TestSubtitles_Write(t *testing.T) {
 	n, err := subtitles.Write(&sb)
 	assert.NoError(t, err, "Expected no error from Write")
 
-	expected := "1\n00:00:01.000 --> 00:00:03.000\nFirst\n\n2\n00:00:04.000 --> 00:00:06.000\nSecond"
+	expected := "1\n00:00:01,000 --> 00:00:03,000\nFirst\n\n2\n00:00:04,000 --> 00:00:06,000\nSecond"
 	assert.Equal(t, expected, sb.String(), "Expected output to be:\n%s\nGot:\n%s", expected, sb.String())
 	assert.Equal(t, len(expected), n, "Expected number of bytes written to be %d, got %d", len(expected), n)
 }
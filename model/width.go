@@ -0,0 +1,86 @@
+package model
+
+import "unicode"
+
+// wideRanges are the East Asian Wide and Fullwidth code points, including
+// the emoji presentation blocks, which terminals and players display on two
+// columns.
+var wideRanges = []struct{ lo, hi rune }{
+	{0x1100, 0x115F},
+	{0x231A, 0x231B},
+	{0x2329, 0x232A},
+	{0x23E9, 0x23EC},
+	{0x2E80, 0x303E},
+	{0x3041, 0x33FF},
+	{0x3400, 0x4DBF},
+	{0x4E00, 0x9FFF},
+	{0xA000, 0xA4CF},
+	{0xA960, 0xA97F},
+	{0xAC00, 0xD7A3},
+	{0xF900, 0xFAFF},
+	{0xFE10, 0xFE19},
+	{0xFE30, 0xFE6F},
+	{0xFF00, 0xFF60},
+	{0xFFE0, 0xFFE6},
+	{0x1F1E6, 0x1F1FF},
+	{0x1F300, 0x1F64F},
+	{0x1F900, 0x1F9FF},
+	{0x20000, 0x2FFFD},
+	{0x30000, 0x3FFFD},
+}
+
+// isWide reports whether r is displayed on two columns.
+func isWide(r rune) bool {
+	for _, w := range wideRanges {
+		if r < w.lo {
+			return false
+		}
+		if r <= w.hi {
+			return true
+		}
+	}
+	return false
+}
+
+// isRegionalIndicator reports whether r is one of the letters that form flag
+// emoji by pairs.
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// TextWidth returns the number of columns needed to display s on a single
+// line. Each grapheme cluster, such as a letter followed by combining
+// accents or an emoji sequence joined by zero width joiners, counts as one
+// column, or two for East Asian wide characters and emoji.
+func TextWidth(s string) int {
+	width := 0
+	joined := false
+	flag := false
+	for _, r := range s {
+		switch {
+		case joined:
+			joined = false
+			continue
+		case r == '\u200d':
+			joined = true
+			continue
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+			continue
+		case isRegionalIndicator(r):
+			// Pairs of regional indicators form a single flag.
+			flag = !flag
+			if !flag {
+				continue
+			}
+		default:
+			flag = false
+		}
+
+		if isWide(r) {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	return width
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected int
+	}{
+		{"ascii", "Hello", 5},
+		{"precomposed accents", "Déjà vu", 7},
+		{"combining accents", "De\u0301ja\u0300 vu", 7},
+		{"wide characters", "日本語", 6},
+		{"fullwidth punctuation", "はい。", 6},
+		{"emoji", "ok 👍", 5},
+		{"emoji sequence joined by zero width joiner", "\U0001F469\u200d\U0001F4BB", 2},
+		{"flag", "🇫🇷", 2},
+		{"two flags", "🇫🇷🇯🇵", 4},
+		{"zero width space", "a\u200bb", 2},
+		{"empty", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, TextWidth(tt.text))
+		})
+	}
+}
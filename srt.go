@@ -3,12 +3,58 @@ package srt
 import (
 	"io"
 	"os"
+	"sort"
+	"strings"
 
-	"github.com/florentsorel/srt/internal/lexer"
+	"github.com/florentsorel/srt/charset"
 	"github.com/florentsorel/srt/internal/parser"
 	"github.com/florentsorel/srt/model"
 )
 
+// Options configures how SRT content is parsed.
+type Options struct {
+	// Lenient makes the parser skip malformed cues instead of failing on the
+	// first error, and replace invalid UTF-8 bytes with U+FFFD. Skipped and
+	// repaired regions are reported in Result.Diagnostics.
+	Lenient bool
+
+	// DetectEncoding enables detection of the input character encoding. The
+	// input is transcoded to UTF-8 before parsing and the detected encoding
+	// is reported in Result.Encoding. Without it, the input must be UTF-8.
+	DetectEncoding bool
+
+	// Encoding forces the input character encoding, overriding detection.
+	Encoding charset.Encoding
+}
+
+// ParseError is the error returned when SRT content cannot be parsed. It
+// carries the position of the offending token and wraps one of the Err*
+// sentinel causes below.
+type ParseError = parser.Error
+
+// Causes wrapped by ParseError, to be tested with errors.Is.
+var (
+	ErrUnexpectedToken = parser.ErrUnexpectedToken
+	ErrBadIndex        = parser.ErrBadIndex
+	ErrBadTimestamp    = parser.ErrBadTimestamp
+	ErrMissingArrow    = parser.ErrMissingArrow
+	ErrEmptyText       = parser.ErrEmptyText
+	ErrInvalidUTF8     = parser.ErrInvalidUTF8
+)
+
+// Diagnostic describes a region of the input skipped or repaired in lenient
+// mode.
+type Diagnostic = parser.Diagnostic
+
+// Result holds the outcome of ParseWithOptions.
+type Result struct {
+	Subtitles   *model.Subtitles
+	Diagnostics []Diagnostic
+	// Encoding is the encoding the input was decoded from. It is only set
+	// when Options.DetectEncoding or Options.Encoding is used.
+	Encoding charset.Encoding
+}
+
 // Open reads the SRT file at the given path, parses its content,
 // and returns a Subtitles struct or an error if reading or parsing fails.
 func Open(path string) (*model.Subtitles, error) {
@@ -29,12 +75,11 @@ func Parse(r io.Reader) (*model.Subtitles, error) {
 		return nil, err
 	}
 
-	l, err := lexer.New(string(b))
+	p, err := parser.NewFromString(string(b))
 	if err != nil {
 		return nil, err
 	}
 
-	p := parser.New(l)
 	cues, err := p.Parse()
 	if err != nil {
 		return nil, err
@@ -42,3 +87,68 @@ func Parse(r io.Reader) (*model.Subtitles, error) {
 
 	return &model.Subtitles{Items: cues}, nil
 }
+
+// OpenWithOptions reads the SRT file at the given path and parses its
+// content according to opts.
+func OpenWithOptions(path string, opts Options) (*Result, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseWithOptions(f, opts)
+}
+
+// ParseWithOptions reads from the provided io.Reader and parses the SRT
+// content according to opts. In lenient mode, malformed cues are skipped and
+// reported as diagnostics instead of aborting the whole parse.
+func ParseWithOptions(r io.Reader, opts Options) (*Result, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var res Result
+	input := string(b)
+
+	if opts.Encoding != "" || opts.DetectEncoding {
+		res.Encoding = opts.Encoding
+		if res.Encoding == "" {
+			res.Encoding = charset.Detect(b)
+		}
+
+		if opts.Lenient && res.Encoding == charset.UTF8 {
+			// Invalid bytes are left for the lenient parser to repair and
+			// report, as they are when no encoding is given.
+			input = strings.TrimPrefix(input, "\ufeff")
+		} else {
+			input, err = charset.Decode(b, res.Encoding)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var cues []model.Cue
+	if opts.Lenient {
+		p, repaired := parser.NewFromStringRepaired(input)
+		cues, res.Diagnostics = p.ParseLenient()
+		res.Diagnostics = append(repaired, res.Diagnostics...)
+		sort.SliceStable(res.Diagnostics, func(i, j int) bool {
+			return res.Diagnostics[i].StartLine < res.Diagnostics[j].StartLine
+		})
+	} else {
+		p, err := parser.NewFromString(input)
+		if err != nil {
+			return nil, err
+		}
+		cues, err = p.Parse()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res.Subtitles = &model.Subtitles{Items: cues}
+	return &res, nil
+}
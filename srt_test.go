@@ -0,0 +1,100 @@
+package srt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/florentsorel/srt/charset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWithOptions(t *testing.T) {
+	input := "1\n00:00:01,000 --> 00:00:02,000\nFirst\n\n2\n00:00:03,000 -> 00:00:04,000\nBroken\n\n3\n00:00:05,000 --> 00:00:06,000\nThird"
+
+	_, err := ParseWithOptions(strings.NewReader(input), Options{})
+	assert.EqualError(t, err, "expected ARROW, got TEXT at line 6, column 14")
+
+	res, err := ParseWithOptions(strings.NewReader(input), Options{Lenient: true})
+	assert.NoError(t, err, "Expected no error in lenient mode")
+	assert.Len(t, res.Subtitles.Items, 2)
+	assert.Equal(t, "First", res.Subtitles.Items[0].Text)
+	assert.Equal(t, "Third", res.Subtitles.Items[1].Text)
+	if assert.Len(t, res.Diagnostics, 1) {
+		assert.Equal(t, 5, res.Diagnostics[0].StartLine)
+		assert.Equal(t, 8, res.Diagnostics[0].EndLine)
+	}
+}
+
+func TestParseWithOptionsLenientRepair(t *testing.T) {
+	input := "1\n00:00:01,000 --> 00:00:02,000\nCaf\xe9\n\n2\n00:00:03,000-->00:00:04,000\nBad\n\n3\n00:00:05,000 --> 00:00:06,000\nGood\n"
+
+	res, err := ParseWithOptions(strings.NewReader(input), Options{Lenient: true})
+	assert.NoError(t, err, "Expected no error in lenient mode")
+	if assert.Len(t, res.Subtitles.Items, 2) {
+		assert.Equal(t, "Caf\ufffd", res.Subtitles.Items[0].Text)
+		assert.Equal(t, "Good", res.Subtitles.Items[1].Text)
+	}
+	if assert.Len(t, res.Diagnostics, 2) {
+		assert.ErrorIs(t, res.Diagnostics[0].Err, ErrInvalidUTF8)
+		assert.Equal(t, 3, res.Diagnostics[0].StartLine)
+		assert.Equal(t, 5, res.Diagnostics[1].StartLine)
+		assert.Equal(t, 8, res.Diagnostics[1].EndLine)
+	}
+}
+
+func TestParseWithOptionsLenientRepairUTF8(t *testing.T) {
+	input := "\xef\xbb\xbf1\n00:00:01,000 --> 00:00:02,000\nCaf\xe9\n"
+
+	_, err := ParseWithOptions(strings.NewReader(input), Options{Encoding: charset.UTF8})
+	assert.Error(t, err, "Expected an error on invalid UTF-8 in strict mode")
+
+	res, err := ParseWithOptions(strings.NewReader(input), Options{Lenient: true, Encoding: charset.UTF8})
+	assert.NoError(t, err, "Expected no error in lenient mode")
+	assert.Equal(t, charset.UTF8, res.Encoding)
+	if assert.Len(t, res.Subtitles.Items, 1) {
+		assert.Equal(t, 1, res.Subtitles.Items[0].Index)
+		assert.Equal(t, "Caf\ufffd", res.Subtitles.Items[0].Text)
+	}
+	if assert.Len(t, res.Diagnostics, 1) {
+		assert.ErrorIs(t, res.Diagnostics[0].Err, ErrInvalidUTF8)
+		assert.Equal(t, 3, res.Diagnostics[0].StartLine)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	_, err := Parse(strings.NewReader("1\n00:00:01,000 -> 00:00:02,000\nText"))
+	assert.ErrorIs(t, err, ErrMissingArrow)
+
+	var parseErr *ParseError
+	if assert.ErrorAs(t, err, &parseErr) {
+		assert.Equal(t, 2, parseErr.Line)
+		assert.Equal(t, 14, parseErr.Column)
+		assert.Equal(t, 1, parseErr.Cue)
+	}
+
+	_, err = Parse(strings.NewReader("1\n00:00:01,000 --> 00:00:02,000\n\xff"))
+	assert.ErrorIs(t, err, ErrInvalidUTF8)
+}
+
+func TestParseWithOptionsEncoding(t *testing.T) {
+	input := "1\r\n00:00:01,000 --> 00:00:02,000\r\nD\xe9j\xe0 vu, tr\xe8s \xe9trange.\r\n"
+
+	_, err := ParseWithOptions(strings.NewReader(input), Options{})
+	assert.ErrorIs(t, err, ErrInvalidUTF8)
+
+	res, err := ParseWithOptions(strings.NewReader(input), Options{DetectEncoding: true})
+	assert.NoError(t, err, "Expected no error with encoding detection")
+	assert.Equal(t, charset.Windows1252, res.Encoding)
+	assert.Equal(t, "Déjà vu, très étrange.", res.Subtitles.Items[0].Text)
+
+	res, err = ParseWithOptions(strings.NewReader(input), Options{DetectEncoding: true, Encoding: charset.Windows1250})
+	assert.NoError(t, err, "Expected no error with explicit encoding")
+	assert.Equal(t, charset.Windows1250, res.Encoding)
+	assert.Equal(t, "Déjŕ vu, trčs étrange.", res.Subtitles.Items[0].Text)
+
+	bom := "\xef\xbb\xbf1\n00:00:01,000 --> 00:00:02,000\nHello"
+	res, err = ParseWithOptions(strings.NewReader(bom), Options{DetectEncoding: true})
+	assert.NoError(t, err, "Expected no error with UTF-8 BOM")
+	assert.Equal(t, charset.UTF8, res.Encoding)
+	assert.Equal(t, 1, res.Subtitles.Items[0].Index)
+}
@@ -0,0 +1,246 @@
+// Package vtt reads and writes WebVTT files using the same data model as
+// the SRT parser, so that tracks can be converted between both formats.
+package vtt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/florentsorel/srt/model"
+)
+
+const arrow = "-->"
+
+// Open reads the WebVTT file at the given path, parses its content,
+// and returns a Subtitles struct or an error if reading or parsing fails.
+func Open(path string) (*model.Subtitles, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Parse reads from the provided io.Reader, parses the WebVTT content,
+// and returns a Subtitles struct or an error if parsing fails.
+//
+// NOTE, STYLE and REGION blocks are skipped. Numeric cue identifiers are
+// kept as the cue Index, and other identifiers in Cue.ID. Cues without a
+// numeric identifier are numbered after the previous cue, skipping the
+// numbers used as identifiers in the file. Cue settings are stored verbatim
+// in Cue.Settings.
+func Parse(r io.Reader) (*model.Subtitles, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("missing WEBVTT header at line 1")
+	}
+	header := strings.TrimPrefix(lines[0], "\ufeff")
+	if header != "WEBVTT" && !strings.HasPrefix(header, "WEBVTT ") && !strings.HasPrefix(header, "WEBVTT\t") {
+		return nil, fmt.Errorf("missing WEBVTT header at line 1")
+	}
+
+	// Skip the rest of the header block.
+	i := 1
+	for i < len(lines) && lines[i] != "" {
+		i++
+	}
+
+	var cues []model.Cue
+	for i < len(lines) {
+		if lines[i] == "" {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(lines) && lines[i] != "" {
+			i++
+		}
+		block := lines[start:i]
+
+		if isBlock(block[0], "NOTE") || isBlock(block[0], "STYLE") || isBlock(block[0], "REGION") {
+			continue
+		}
+
+		cue, err := parseCue(block, start+1)
+		if err != nil {
+			return nil, err
+		}
+		cues = append(cues, cue)
+	}
+
+	used := make(map[int]bool)
+	for _, cue := range cues {
+		used[cue.Index] = true
+	}
+	for i := range cues {
+		if cues[i].Index != 0 {
+			continue
+		}
+		index := 1
+		if i > 0 {
+			index = cues[i-1].Index + 1
+		}
+		for used[index] {
+			index++
+		}
+		cues[i].Index = index
+		used[index] = true
+	}
+
+	return &model.Subtitles{Items: cues}, nil
+}
+
+// isBlock reports whether line starts a block of the given kind, that is the
+// keyword alone or followed by a space or a tab.
+func isBlock(line, keyword string) bool {
+	if !strings.HasPrefix(line, keyword) {
+		return false
+	}
+	rest := line[len(keyword):]
+	return rest == "" || rest[0] == ' ' || rest[0] == '\t'
+}
+
+// parseCue parses a cue block whose first line is at the given line number.
+func parseCue(block []string, line int) (model.Cue, error) {
+	var c model.Cue
+
+	if !strings.Contains(block[0], arrow) {
+		id := strings.TrimSpace(block[0])
+		if index, err := strconv.Atoi(id); err == nil && index > 0 {
+			c.Index = index
+		} else {
+			c.ID = id
+		}
+		block = block[1:]
+		line++
+	}
+
+	if len(block) == 0 || !strings.Contains(block[0], arrow) {
+		return c, fmt.Errorf("expected cue timings at line %d", line)
+	}
+
+	timing := block[0]
+	sep := strings.Index(timing, arrow)
+	start, err := parseTime(strings.TrimSpace(timing[:sep]))
+	if err != nil {
+		return c, fmt.Errorf("%v at line %d", err, line)
+	}
+
+	fields := strings.Fields(timing[sep+len(arrow):])
+	if len(fields) == 0 {
+		return c, fmt.Errorf("missing end timestamp at line %d", line)
+	}
+	end, err := parseTime(fields[0])
+	if err != nil {
+		return c, fmt.Errorf("%v at line %d", err, line)
+	}
+
+	c.Start = start
+	c.End = end
+	c.Settings = strings.Join(fields[1:], " ")
+	c.Text = strings.Join(block[1:], "\n")
+
+	return c, nil
+}
+
+// parseTime parses a WebVTT timestamp in the format "HH:MM:SS.mmm" or
+// "MM:SS.mmm" and returns a model.Duration. Hours may have more than two
+// digits.
+func parseTime(s string) (model.Duration, error) {
+	invalid := fmt.Errorf("invalid timestamp %q", s)
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return 0, invalid
+	}
+
+	var h int
+	if len(parts) == 3 {
+		if len(parts[0]) < 2 || !isDigits(parts[0]) {
+			return 0, invalid
+		}
+		h, _ = strconv.Atoi(parts[0])
+		parts = parts[1:]
+	}
+
+	// parts[0] is MM, parts[1] is SS.mmm
+	if len(parts[0]) != 2 || !isDigits(parts[0]) {
+		return 0, invalid
+	}
+	if len(parts[1]) != 6 || parts[1][2] != '.' || !isDigits(parts[1][:2]) || !isDigits(parts[1][3:]) {
+		return 0, invalid
+	}
+
+	m, _ := strconv.Atoi(parts[0])
+	sec, _ := strconv.Atoi(parts[1][:2])
+	ms, _ := strconv.Atoi(parts[1][3:])
+	if m > 59 || sec > 59 {
+		return 0, invalid
+	}
+
+	d := time.Duration(h)*time.Hour +
+		time.Duration(m)*time.Minute +
+		time.Duration(sec)*time.Second +
+		time.Duration(ms)*time.Millisecond
+
+	return model.Duration(d), nil
+}
+
+// isDigits reports whether s is made only of ASCII digits.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Write writes the Subtitles in WebVTT format to the given io.Writer.
+// Cue.ID, or else the cue index, is written as the cue identifier and
+// Cue.Settings is appended to the timing line.
+func Write(writer io.Writer, s model.Subtitles) (int, error) {
+	var b strings.Builder
+
+	b.WriteString("WEBVTT\n")
+	for _, cue := range s.Items {
+		b.WriteByte('\n')
+		if cue.ID != "" {
+			b.WriteString(cue.ID)
+			b.WriteByte('\n')
+		} else if cue.Index > 0 {
+			b.WriteString(strconv.Itoa(cue.Index))
+			b.WriteByte('\n')
+		}
+		b.WriteString(cue.Start.String())
+		b.WriteString(" --> ")
+		b.WriteString(cue.End.String())
+		if cue.Settings != "" {
+			b.WriteByte(' ')
+			b.WriteString(cue.Settings)
+		}
+		b.WriteByte('\n')
+		b.WriteString(cue.Text)
+		b.WriteByte('\n')
+	}
+
+	return writer.Write([]byte(b.String()))
+}
@@ -0,0 +1,102 @@
+package vtt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/florentsorel/srt"
+	"github.com/florentsorel/srt/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	input := "\ufeffWEBVTT - Sample\r\nKind: captions\r\n\r\n" +
+		"NOTE This is a comment\r\nspanning two lines\r\n\r\n" +
+		"STYLE\r\n::cue { color: yellow }\r\n\r\n" +
+		"1\r\n00:01.000 --> 00:04.000\r\nHello\r\nWorld\r\n\r\n" +
+		"intro\r\n00:00:05.500 --> 00:00:07.000 line:0 position:50% align:start\r\n<i>Second</i>\r\n\r\n" +
+		"01:00:00.000 --> 01:00:01.250\r\nThird\r\n"
+
+	subtitles, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err, "Expected no error from Parse")
+
+	expected := []model.Cue{
+		{Index: 1, Start: model.Duration(time.Second), End: model.Duration(4 * time.Second), Text: "Hello\nWorld"},
+		{Index: 2, Start: model.Duration(5*time.Second + 500*time.Millisecond), End: model.Duration(7 * time.Second), Text: "<i>Second</i>", Settings: "line:0 position:50% align:start", ID: "intro"},
+		{Index: 3, Start: model.Duration(time.Hour), End: model.Duration(time.Hour + time.Second + 250*time.Millisecond), Text: "Third"},
+	}
+	assert.Equal(t, expected, subtitles.Items)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"", "missing WEBVTT header at line 1"},
+		{"WEBVTTX\n\n", "missing WEBVTT header at line 1"},
+		{"WEBVTT\n\nid\nHello", "expected cue timings at line 4"},
+		{"WEBVTT\n\n00:00:01,000 --> 00:00:02.000\nHello", `invalid timestamp "00:00:01,000" at line 3`},
+		{"WEBVTT\n\n00:00:01.000 --> 00:61.000\nHello", `invalid timestamp "00:61.000" at line 3`},
+		{"WEBVTT\n\n00:00:01.000 -->\nHello", "missing end timestamp at line 3"},
+	}
+
+	for i, tt := range tests {
+		_, err := Parse(strings.NewReader(tt.input))
+		assert.EqualError(t, err, tt.err, "[%d] expected=%q, got=%q.", i, tt.err, err)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	subtitles := model.Subtitles{
+		Items: []model.Cue{
+			{Index: 1, Start: model.Duration(1 * time.Second), End: model.Duration(3 * time.Second), Text: "First"},
+			{Index: 2, Start: model.Duration(4 * time.Second), End: model.Duration(6 * time.Second), Text: "Second", Settings: "align:start"},
+		},
+	}
+
+	var sb strings.Builder
+	n, err := Write(&sb, subtitles)
+	assert.NoError(t, err, "Expected no error from Write")
+
+	expected := "WEBVTT\n\n1\n00:00:01.000 --> 00:00:03.000\nFirst\n\n2\n00:00:04.000 --> 00:00:06.000 align:start\nSecond\n"
+	assert.Equal(t, expected, sb.String())
+	assert.Equal(t, len(expected), n)
+}
+
+func TestRoundTripIdentifiers(t *testing.T) {
+	input := "WEBVTT\n\nintro\n00:00:01.000 --> 00:00:02.000\nFirst\n\n00:00:03.000 --> 00:00:04.000\nSecond\n\n2\n00:00:05.000 --> 00:00:06.000\nThird\n"
+
+	subtitles, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err, "Expected no error from Parse")
+	assert.Equal(t, []model.Cue{
+		{Index: 1, Start: model.Duration(1 * time.Second), End: model.Duration(2 * time.Second), Text: "First", ID: "intro"},
+		{Index: 3, Start: model.Duration(3 * time.Second), End: model.Duration(4 * time.Second), Text: "Second"},
+		{Index: 2, Start: model.Duration(5 * time.Second), End: model.Duration(6 * time.Second), Text: "Third"},
+	}, subtitles.Items)
+
+	var sb strings.Builder
+	_, err = Write(&sb, *subtitles)
+	assert.NoError(t, err, "Expected no error from Write")
+	assert.Equal(t, "WEBVTT\n\nintro\n00:00:01.000 --> 00:00:02.000\nFirst\n\n3\n00:00:03.000 --> 00:00:04.000\nSecond\n\n2\n00:00:05.000 --> 00:00:06.000\nThird\n", sb.String())
+
+	reparsed, err := Parse(strings.NewReader(sb.String()))
+	assert.NoError(t, err, "Expected no error from Parse")
+	assert.Equal(t, subtitles.Items, reparsed.Items)
+}
+
+func TestRoundTripWithSRT(t *testing.T) {
+	input := "1\n00:00:01,000 --> 00:00:02,500\nFirst\nline\n\n2\n00:01:03,042 --> 01:00:04,000\n<i>Second</i>"
+
+	fromSRT, err := srt.Parse(strings.NewReader(input))
+	assert.NoError(t, err, "Expected no error from srt.Parse")
+
+	var sb strings.Builder
+	_, err = Write(&sb, *fromSRT)
+	assert.NoError(t, err, "Expected no error from Write")
+
+	fromVTT, err := Parse(strings.NewReader(sb.String()))
+	assert.NoError(t, err, "Expected no error from Parse")
+	assert.Equal(t, fromSRT.Items, fromVTT.Items)
+}
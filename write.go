@@ -0,0 +1,159 @@
+package srt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/florentsorel/srt/charset"
+	"github.com/florentsorel/srt/internal/lexer"
+	"github.com/florentsorel/srt/internal/token"
+	"github.com/florentsorel/srt/model"
+)
+
+// Numbering is the policy used to write cue indexes.
+type Numbering int
+
+const (
+	// KeepNumbering writes the Index of each cue as is.
+	KeepNumbering Numbering = iota
+	// Renumber writes cues numbered sequentially from 1.
+	Renumber
+)
+
+// Errors returned by Write for cues that cannot be represented in SRT.
+var (
+	ErrNegativeTimestamp   = errors.New("negative timestamp")
+	ErrUnwritableTimestamp = errors.New("timestamp cannot be written as SRT")
+	ErrNegativeIndex       = errors.New("negative index")
+	ErrUnwritableText      = errors.New("text cannot be written as SRT")
+)
+
+// maxTimestamp is the largest time with a two-digit hour, 99:59:59,999.
+const maxTimestamp = 100*time.Hour - time.Millisecond
+
+// WriteOptions configures how subtitles are serialized by Write. The zero
+// value produces the same output as model.Subtitles.Write.
+type WriteOptions struct {
+	// CRLF uses "\r\n" line endings instead of "\n".
+	CRLF bool
+	// BOM writes a byte order mark first, for encodings that have one.
+	BOM bool
+	// TrailingNewline ends the output with a line ending after the last cue.
+	TrailingNewline bool
+	// Numbering selects how cue indexes are written.
+	Numbering Numbering
+	// Encoding is the character encoding of the output. It defaults to
+	// UTF-8.
+	Encoding charset.Encoding
+}
+
+// Write writes the Subtitles in SRT format to the given io.Writer, using
+// "HH:MM:SS,mmm" timestamps.
+//
+// Cues are validated before anything is written, so that parsing the output
+// gives back the same cues: timestamps and indexes must not be negative,
+// timestamps must be whole milliseconds below 100 hours, and the text must be
+// non-empty, without blank lines and with every line read back as text (for
+// instance, not a lone number or a timestamp, and without leading spaces).
+//
+// SRT has no place for cue settings or identifiers other than the index, so
+// Cue.Settings and Cue.ID are not written: they are lost when converting
+// from WebVTT, for instance.
+func Write(w io.Writer, s model.Subtitles, opts WriteOptions) (int, error) {
+	for i, cue := range s.Items {
+		if err := validateCue(cue, opts.Numbering); err != nil {
+			return 0, fmt.Errorf("cue %d: %w", i+1, err)
+		}
+	}
+
+	newline := "\n"
+	if opts.CRLF {
+		newline = "\r\n"
+	}
+
+	var b strings.Builder
+	for i, cue := range s.Items {
+		if i > 0 {
+			b.WriteString(newline)
+			b.WriteString(newline)
+		}
+
+		index := cue.Index
+		if opts.Numbering == Renumber {
+			index = i + 1
+		}
+
+		b.WriteString(strconv.Itoa(index))
+		b.WriteString(newline)
+		b.WriteString(cue.Start.SRTString())
+		b.WriteString(" --> ")
+		b.WriteString(cue.End.SRTString())
+		b.WriteString(newline)
+		b.WriteString(strings.ReplaceAll(cue.Text, "\n", newline))
+	}
+	if opts.TrailingNewline && len(s.Items) > 0 {
+		b.WriteString(newline)
+	}
+
+	enc := opts.Encoding
+	if enc == "" {
+		enc = charset.UTF8
+	}
+
+	out, err := charset.Encode(b.String(), enc)
+	if err != nil {
+		return 0, err
+	}
+	if opts.BOM {
+		out = append(append([]byte(nil), charset.BOM(enc)...), out...)
+	}
+
+	return w.Write(out)
+}
+
+// validateCue checks that the cue can be written and parsed back unchanged.
+func validateCue(c model.Cue, numbering Numbering) error {
+	for _, d := range []model.Duration{c.Start, c.End} {
+		switch {
+		case d < 0:
+			return ErrNegativeTimestamp
+		case time.Duration(d) > maxTimestamp:
+			return fmt.Errorf("%w: %v is 100 hours or more", ErrUnwritableTimestamp, time.Duration(d))
+		case time.Duration(d)%time.Millisecond != 0:
+			return fmt.Errorf("%w: %v is not a whole number of milliseconds", ErrUnwritableTimestamp, time.Duration(d))
+		}
+	}
+	if numbering == KeepNumbering && c.Index < 0 {
+		return ErrNegativeIndex
+	}
+	if c.Text == "" {
+		return fmt.Errorf("%w: empty text", ErrUnwritableText)
+	}
+
+	for _, line := range strings.Split(c.Text, "\n") {
+		if !isTextLine(line) {
+			return fmt.Errorf("%w: line %q", ErrUnwritableText, line)
+		}
+	}
+	return nil
+}
+
+// isTextLine reports whether line is lexed back as a single TEXT token with
+// the same content.
+func isTextLine(line string) bool {
+	if line == "" || strings.ContainsRune(line, '\r') {
+		return false
+	}
+
+	l, err := lexer.New(line)
+	if err != nil {
+		return false
+	}
+
+	tok := l.NextToken()
+	return tok.Kind == token.TEXT && tok.Literal == line && l.NextToken().Kind == token.EOF
+}
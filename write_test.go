@@ -0,0 +1,206 @@
+package srt
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/florentsorel/srt/charset"
+	"github.com/florentsorel/srt/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	subtitles := model.Subtitles{
+		Items: []model.Cue{
+			{Index: 5, Start: model.Duration(1 * time.Second), End: model.Duration(3*time.Second + 250*time.Millisecond), Text: "First\nline"},
+			{Index: 9, Start: model.Duration(4 * time.Second), End: model.Duration(6 * time.Second), Text: "Café"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		opts     WriteOptions
+		expected string
+	}{
+		{
+			name:     "default",
+			opts:     WriteOptions{},
+			expected: "5\n00:00:01,000 --> 00:00:03,250\nFirst\nline\n\n9\n00:00:04,000 --> 00:00:06,000\nCafé",
+		},
+		{
+			name:     "crlf_trailing_renumber",
+			opts:     WriteOptions{CRLF: true, TrailingNewline: true, Numbering: Renumber},
+			expected: "1\r\n00:00:01,000 --> 00:00:03,250\r\nFirst\r\nline\r\n\r\n2\r\n00:00:04,000 --> 00:00:06,000\r\nCafé\r\n",
+		},
+		{
+			name:     "bom",
+			opts:     WriteOptions{BOM: true},
+			expected: "\ufeff5\n00:00:01,000 --> 00:00:03,250\nFirst\nline\n\n9\n00:00:04,000 --> 00:00:06,000\nCafé",
+		},
+		{
+			name:     "windows1252",
+			opts:     WriteOptions{Encoding: charset.Windows1252, BOM: true},
+			expected: "5\n00:00:01,000 --> 00:00:03,250\nFirst\nline\n\n9\n00:00:04,000 --> 00:00:06,000\nCaf\xe9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := Write(&buf, subtitles, tt.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, buf.String())
+			assert.Equal(t, len(tt.expected), n)
+		})
+	}
+}
+
+func TestWriteDefaultMatchesSubtitlesWrite(t *testing.T) {
+	subtitles := model.Subtitles{
+		Items: []model.Cue{
+			{Index: 1, Start: model.Duration(1 * time.Second), End: model.Duration(3 * time.Second), Text: "First"},
+			{Index: 2, Start: model.Duration(4 * time.Second), End: model.Duration(6 * time.Second), Text: "Second"},
+		},
+	}
+
+	var expected, got strings.Builder
+	_, err := subtitles.Write(&expected)
+	assert.NoError(t, err)
+	_, err = Write(&got, subtitles, WriteOptions{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, expected.String(), got.String())
+}
+
+func TestWriteErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cue  model.Cue
+		err  error
+	}{
+		{"negative_start", model.Cue{Index: 1, Start: model.Duration(-time.Second), End: 0, Text: "Text"}, ErrNegativeTimestamp},
+		{"hundred_hours", model.Cue{Index: 1, Start: 0, End: model.Duration(100 * time.Hour), Text: "Text"}, ErrUnwritableTimestamp},
+		{"sub_millisecond", model.Cue{Index: 1, Start: model.Duration(time.Second + time.Microsecond), End: model.Duration(2 * time.Second), Text: "Text"}, ErrUnwritableTimestamp},
+		{"negative_index", model.Cue{Index: -1, Text: "Text"}, ErrNegativeIndex},
+		{"empty_text", model.Cue{Index: 1, Text: ""}, ErrUnwritableText},
+		{"blank_line", model.Cue{Index: 1, Text: "First\n\nSecond"}, ErrUnwritableText},
+		{"number_line", model.Cue{Index: 1, Text: "Room\n42"}, ErrUnwritableText},
+		{"timestamp_line", model.Cue{Index: 1, Text: "00:00:01,000"}, ErrUnwritableText},
+		{"leading_space", model.Cue{Index: 1, Text: " indented"}, ErrUnwritableText},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			_, err := Write(&buf, model.Subtitles{Items: []model.Cue{tt.cue}}, WriteOptions{})
+			assert.ErrorIs(t, err, tt.err)
+			assert.Zero(t, buf.Len(), "Expected nothing to be written on error")
+		})
+	}
+}
+
+// randomSubtitles generates a track of cues, some of which cannot be
+// represented in SRT: times may reach 100 hours or not be whole
+// milliseconds, and lines may be read back as something else than text.
+func randomSubtitles(r *rand.Rand) model.Subtitles {
+	words := []string{"hello", "world", "Ça", "va", "?", "😀", "13,23", "euros", "-", "<i>yes</i>", "89", "street", "漢字", "{\\an8}", "-->", "a:b", "00:00:01,000", " "}
+
+	var items []model.Cue
+	if n := r.Intn(20); n > 0 {
+		items = make([]model.Cue, n)
+	}
+
+	var at time.Duration
+	if r.Intn(10) == 0 {
+		at = 100*time.Hour - time.Duration(r.Intn(20000))*time.Millisecond
+	}
+	for i := range items {
+		at += time.Duration(r.Intn(5000)) * time.Millisecond
+		length := time.Duration(1+r.Intn(4000)) * time.Millisecond
+		if r.Intn(50) == 0 {
+			length += time.Duration(1 + r.Intn(999))
+		}
+
+		lines := make([]string, 1+r.Intn(3))
+		for j := range lines {
+			// Start most lines with a letter so that enough tracks are
+			// written and read back.
+			var line []string
+			if r.Intn(10) > 0 {
+				line = append(line, "L")
+			}
+			for k := r.Intn(5); k >= 0; k-- {
+				line = append(line, words[r.Intn(len(words))])
+			}
+			lines[j] = strings.Join(line, " ")
+		}
+
+		items[i] = model.Cue{
+			Index: i + 1 + r.Intn(3),
+			Start: model.Duration(at),
+			End:   model.Duration(at + length),
+			Text:  strings.Join(lines, "\n"),
+		}
+	}
+	return model.Subtitles{Items: items}
+}
+
+// hasUnwritableTime reports whether a cue of s starts or ends at 100 hours
+// or later, or not on a whole millisecond.
+func hasUnwritableTime(s model.Subtitles) bool {
+	for _, cue := range s.Items {
+		for _, d := range []time.Duration{time.Duration(cue.Start), time.Duration(cue.End)} {
+			if d >= 100*time.Hour || d%time.Millisecond != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	written := 0
+	for i := 0; i < 1000; i++ {
+		subtitles := randomSubtitles(r)
+		opts := WriteOptions{
+			CRLF:            r.Intn(2) == 0,
+			BOM:             r.Intn(2) == 0,
+			TrailingNewline: r.Intn(2) == 0,
+			Numbering:       Numbering(r.Intn(2)),
+		}
+
+		var buf bytes.Buffer
+		_, err := Write(&buf, subtitles, opts)
+		if hasUnwritableTime(subtitles) {
+			assert.Error(t, err, "[%d] Expected an error for unwritable times", i)
+		}
+		if err != nil {
+			assert.True(t, errors.Is(err, ErrUnwritableTimestamp) || errors.Is(err, ErrUnwritableText), "[%d] Unexpected error %v", i, err)
+			assert.Zero(t, buf.Len(), "[%d] Expected nothing to be written on error", i)
+			continue
+		}
+		written++
+
+		parsed, err := Parse(&buf)
+		if !assert.NoError(t, err, "[%d] Expected no error from Parse with %+v", i, opts) {
+			continue
+		}
+
+		expected := subtitles.Items
+		if opts.Numbering == Renumber && expected != nil {
+			expected = make([]model.Cue, len(subtitles.Items))
+			copy(expected, subtitles.Items)
+			for j := range expected {
+				expected[j].Index = j + 1
+			}
+		}
+		assert.Equal(t, expected, parsed.Items, "[%d] Expected round trip with %+v", i, opts)
+	}
+	assert.Greater(t, written, 200, "Expected enough tracks to be written")
+}